@@ -0,0 +1,59 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dathan/go-grpc-video-call-manager/internal/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// setup logging
+func init() {
+	l := &logrus.TextFormatter{ForceColors: true, FullTimestamp: true, TimestampFormat: "2006-01-02 15:04:05"}
+	logrus.SetFormatter(l)
+	logrus.SetLevel(logrus.InfoLevel)
+}
+
+// create a local certificate authority and issue the server and client certs for the tls section of config.json
+func main() {
+	dir := flag.String("dir", filepath.Join("conf", "certs"), "directory to write the certificates to")
+	hosts := flag.String("hosts", "localhost,127.0.0.1", "comma separated names and ips the server cert is valid for")
+	days := flag.Int("days", 365, "how many days the certificates are valid")
+	flag.Parse()
+
+	if err := os.MkdirAll(*dir, 0700); err != nil {
+		logrus.Fatalf("Cannot create %s: %v", *dir, err)
+	}
+
+	validFor := time.Duration(*days) * 24 * time.Hour
+	ca, err := utils.GenerateCA("go-grpc-video-call-manager CA", validFor)
+	if err != nil {
+		logrus.Fatalf("Cannot create the CA: %v", err)
+	}
+
+	server, err := ca.Issue("meet-server", strings.Split(*hosts, ","), false, validFor)
+	if err != nil {
+		logrus.Fatalf("Cannot issue the server cert: %v", err)
+	}
+
+	client, err := ca.Issue("meet-client", nil, true, validFor)
+	if err != nil {
+		logrus.Fatalf("Cannot issue the client cert: %v", err)
+	}
+
+	for name, ck := range map[string]*utils.CertKey{"ca": ca, "server": server, "client": client} {
+		if err := ck.WritePEM(*dir, name); err != nil {
+			logrus.Fatalf("Cannot write the %s cert: %v", name, err)
+		}
+	}
+
+	logrus.Infof("Certificates written to %s, add this to config.json:", *dir)
+	logrus.Infof(`"tls": {"ca_file": %q, "cert_file": %q, "key_file": %q, "client_auth": true, "client_cert_file": %q, "client_key_file": %q}`,
+		filepath.Join(*dir, "ca.pem"),
+		filepath.Join(*dir, "server.pem"), filepath.Join(*dir, "server-key.pem"),
+		filepath.Join(*dir, "client.pem"), filepath.Join(*dir, "client-key.pem"))
+}
@@ -5,11 +5,13 @@ import (
 	"log"
 	"os"
 	"os/signal"
-	"path/filepath"
 	"syscall"
+	"time"
 
 	meettask "github.com/dathan/go-grpc-video-call-manager/internal/tasks"
+	"github.com/dathan/go-grpc-video-call-manager/internal/tracing"
 	"github.com/dathan/go-grpc-video-call-manager/internal/utils"
+	"github.com/dathan/go-grpc-video-call-manager/pkg/history"
 	"github.com/dathan/go-grpc-video-call-manager/pkg/tasks"
 	"github.com/sirupsen/logrus"
 )
@@ -31,53 +33,72 @@ func main() {
 	ctx, cancel := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
 	defer cancel()
 
-	pwd, err := os.Getwd()
+	configPaths, err := utils.SearchPaths("config.json")
 	if err != nil {
 		log.Fatalf("Failed to get current working directory: %v", err)
 	}
 
-	credentialsPaths := []string{
-		filepath.Join(pwd, "credentials.json"),                                           // current directory
-		filepath.Join(pwd, "..", "conf", "credentials.json"),                             // conf directory
-		filepath.Join(pwd, "conf", "credentials.json"),                                   // conf directory
-		filepath.Join(pwd, "..", "cmd", "launch_google_meet_chrome", "credentials.json"), // ../cmd/launch_google_meet_chrome directory
-	}
-
-	configPaths := []string{
-		filepath.Join(pwd, "config.json"),                                           // current directory
-		filepath.Join(pwd, "..", "conf", "config.json"),                             // conf directory
-		filepath.Join(pwd, "conf", "config.json"),                                   // conf directory
-		filepath.Join(pwd, "..", "cmd", "launch_google_meet_chrome", "config.json"), // ../cmd/launch_google_meet_chrome directory
-
-	}
-
 	config, err := utils.LoadConfig(configPaths)
 	if err != nil {
 		panic("ERROR!!! Cannot load config: " + err.Error())
 	}
 
-	d, err := utils.GetFileContents(credentialsPaths)
+	shutdownTracing, err := tracing.Setup(ctx, config.Tracing)
 	if err != nil {
-		panic("ERROR!! Cannot load credentials!! " + err.Error())
+		panic("ERROR!!! Cannot set up tracing: " + err.Error())
 	}
 
-	config.Credentials = d
 	serverReady := make(chan struct{})
 
-	// start the server
-	go meettask.GRPCServer(ctx, config, serverReady)
+	// an agent only runs the browser, the scheduler it registers with polls the calendar
+	if config.Agent != nil {
+		go meettask.GRPCServer(ctx, config, nil, serverReady)
+		<-serverReady
 
-	// Block until the server is ready - this fixes a case when getTasks sees it needs to launch a server yet the GRPC server is not up
-	<-serverReady
+		if err := meettask.RegisterWithScheduler(ctx, config); err != nil {
+			panic("ERROR!!! Cannot register with the scheduler: " + err.Error())
+		}
+		exit(shutdownTracing)
+	}
+
+	// only the google calendar needs credentials.json, ics, caldav and graph do without
+	if config.UsesGoogle() {
+		credentialsPaths, err := utils.SearchPaths("credentials.json")
+		if err != nil {
+			log.Fatalf("Failed to get current working directory: %v", err)
+		}
+
+		d, err := utils.GetFileContents(credentialsPaths)
+		if err != nil {
+			panic("ERROR!! Cannot load credentials!! " + err.Error())
+		}
+
+		config.Credentials = d
+	}
 
 	// get tasks that implement the interface
-	t, err := meettask.GetTasks(config)
+	t, err := meettask.GetTasks(ctx, config)
 	if err != nil {
 		panic(err)
 	}
 	// Convert the tasks into a cron task
 	cron := tasks.NewCron(ctx, t, config)
 
+	historyFile := config.HistoryFile
+	if historyFile == "" {
+		historyFile = "history.jsonl"
+	}
+	cron.History, err = history.Open(historyFile)
+	if err != nil {
+		panic("ERROR!!! Cannot open the history: " + err.Error())
+	}
+
+	// start the server, it reports on the cron's schedule
+	go meettask.GRPCServer(ctx, config, cron, serverReady)
+
+	// Block until the server is ready - this fixes a case when the cron sees it needs to launch a meeting yet the GRPC server is not up
+	<-serverReady
+
 	// refresh the task list
 	go meettask.UpdateCronMeetings(ctx, cron)
 
@@ -85,7 +106,17 @@ func main() {
 	go cron.Run()
 
 	<-ctx.Done()
+	exit(shutdownTracing)
+}
+
+// exit flushes what is buffered and ends the process
+func exit(shutdownTracing func(context.Context) error) {
 	logrus.Println("SHUTDOWN")
+
+	// os.Exit skips the defers, send the spans that are still buffered
+	flushCtx, flushCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	shutdownTracing(flushCtx)
+	flushCancel()
 	//trace.Start(os.Stderr)
 	os.Exit(0)
 }
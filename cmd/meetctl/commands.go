@@ -0,0 +1,210 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+
+	meettask "github.com/dathan/go-grpc-video-call-manager/internal/tasks"
+	"github.com/dathan/go-grpc-video-call-manager/pkg/manager"
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// join a meeting now
+func join(ctx context.Context, conn *grpc.ClientConn, args []string) error {
+	fs := flag.NewFlagSet("join", flag.ExitOnError)
+	mic := fs.Bool("mic", false, "join with the mic on")
+	camera := fs.Bool("camera", false, "join with the camera on")
+	name := fs.String("name", "", "the name to join with when the browser is not logged in")
+	windowed := fs.Bool("windowed", false, "open the browser in a window instead of fullscreen")
+	prejoin := fs.Bool("prejoin", false, "stop at the pre-join screen instead of joining")
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		return errors.New("join takes the meeting link")
+	}
+
+	meet := &manager.Meet{
+		Uri: fs.Arg(0),
+		Options: &manager.JoinOptions{
+			MicOn:         *mic,
+			CameraOn:      *camera,
+			DisplayName:   *name,
+			StayOnPrejoin: *prejoin,
+		},
+	}
+	if *windowed {
+		meet.Options.WindowMode = manager.WindowMode_WINDOWED
+	}
+
+	st, err := manager.NewOpenMeetUrlClient(conn).OpenMeetUrl(ctx, meet)
+	if err != nil {
+		return err
+	}
+
+	return output(st, func() {
+		fmt.Fprintf(stdout, "%s session %s => %s\n", st.Decision, st.SessionId, meet.Uri)
+	})
+}
+
+// list the schedule
+func schedule(ctx context.Context, conn *grpc.ClientConn, args []string) error {
+	sched, err := manager.NewSchedulerClient(conn).ListSchedule(ctx, &emptypb.Empty{})
+	if err != nil {
+		return err
+	}
+
+	return output(sched, func() {
+		w := table()
+		fmt.Fprintln(w, "ID\tFIRES\tSTART\tSTATE\tOVERRIDE\tNAME")
+		for _, t := range sched.Tasks {
+			name := t.Name
+			if t.AdHoc {
+				name += " (queued)"
+			}
+			if t.Error != "" {
+				name += " error: " + t.Error
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", t.Id, when(t.FireTime), when(t.Start), t.State, t.Override, name)
+		}
+		w.Flush()
+	})
+}
+
+// skip a scheduled task
+func skip(ctx context.Context, conn *grpc.ClientConn, args []string) error {
+	if len(args) != 1 {
+		return errors.New("skip takes the task id from meetctl schedule")
+	}
+
+	t, err := manager.NewSchedulerClient(conn).SkipTask(ctx, &manager.TaskRequest{Id: args[0]})
+	if err != nil {
+		return err
+	}
+
+	return output(t, func() {
+		fmt.Fprintf(stdout, "skipping %s %s\n", t.Id, t.Name)
+	})
+}
+
+// show the health of the daemon and the meetings it has open
+func showStatus(ctx context.Context, conn *grpc.ClientConn, args []string) error {
+	hc := healthpb.NewHealthClient(conn)
+	health := map[string]healthpb.HealthCheckResponse_ServingStatus{}
+	parts := map[string]proto.Message{}
+	for _, service := range []string{"", meettask.HealthBrowser, meettask.HealthCalendar} {
+		resp, err := hc.Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+		if err != nil {
+			return err
+		}
+		health[service] = resp.Status
+		if service == "" {
+			service = "daemon"
+		}
+		parts[service] = resp
+	}
+
+	active, err := manager.NewOpenMeetUrlClient(conn).ListActiveMeetings(ctx, &emptypb.Empty{})
+	if err != nil {
+		return err
+	}
+	parts["meetings"] = active
+
+	if jsonOutput {
+		return outputJSON(parts)
+	}
+
+	fmt.Fprintf(stdout, "daemon: %s browser: %s calendar: %s\n", health[""], health[meettask.HealthBrowser], health[meettask.HealthCalendar])
+	if len(active.Meetings) == 0 {
+		fmt.Fprintln(stdout, "no active meetings")
+		return nil
+	}
+
+	w := table()
+	fmt.Fprintln(w, "SESSION\tSTARTED\tSTATE\tURI")
+	for _, m := range active.Meetings {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", m.SessionId, when(m.Started), m.State, m.Uri)
+	}
+	return w.Flush()
+}
+
+// list the browser agents registered with the scheduler
+func listAgents(ctx context.Context, conn *grpc.ClientConn, args []string) error {
+	list, err := manager.NewDispatcherClient(conn).ListAgents(ctx, &emptypb.Empty{})
+	if err != nil {
+		return err
+	}
+
+	return output(list, func() {
+		w := table()
+		fmt.Fprintln(w, "NAME\tADDRESS\tSEEN\tLABELS\tCAPABILITIES")
+		for _, a := range list.Agents {
+			labels := make([]string, 0, len(a.Labels))
+			for k, v := range a.Labels {
+				labels = append(labels, k+"="+v)
+			}
+			sort.Strings(labels)
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", a.Name, a.Address, when(a.LastSeen), strings.Join(labels, ","), strings.Join(a.Capabilities, ","))
+		}
+		w.Flush()
+	})
+}
+
+// calendarPoll shows the events the last calendar poll read and why each was kept or dropped
+func calendarPoll(ctx context.Context, conn *grpc.ClientConn, args []string) error {
+	poll, err := manager.NewSchedulerClient(conn).GetCalendarPoll(ctx, &emptypb.Empty{})
+	if err != nil {
+		return err
+	}
+
+	return output(poll, func() {
+		fmt.Fprintf(stdout, "polled %s for %s to %s\n", when(poll.Time), when(poll.From), when(poll.To))
+		w := table()
+		fmt.Fprintln(w, "START\tKEPT\tCALENDAR\tREASON\tSUMMARY")
+		for _, e := range poll.Events {
+			kept := "no"
+			if e.Kept {
+				kept = "yes"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", when(e.Start), kept, e.Calendar, e.Reason, e.Summary)
+		}
+		w.Flush()
+	})
+}
+
+// leave a meeting, the only one when no session is given
+func leave(ctx context.Context, conn *grpc.ClientConn, args []string) error {
+	client := manager.NewOpenMeetUrlClient(conn)
+
+	var id string
+	switch len(args) {
+	case 1:
+		id = args[0]
+	case 0:
+		active, err := client.ListActiveMeetings(ctx, &emptypb.Empty{})
+		if err != nil {
+			return err
+		}
+		if len(active.Meetings) != 1 {
+			return fmt.Errorf("there are %d active meetings, name the session to leave", len(active.Meetings))
+		}
+		id = active.Meetings[0].SessionId
+	default:
+		return errors.New("leave takes at most one session id")
+	}
+
+	st, err := client.LeaveMeeting(ctx, &manager.SessionRequest{SessionId: id})
+	if err != nil {
+		return err
+	}
+
+	return output(st, func() {
+		fmt.Fprintf(stdout, "leaving session %s\n", st.SessionId)
+	})
+}
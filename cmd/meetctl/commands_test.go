@@ -0,0 +1,146 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	meettask "github.com/dathan/go-grpc-video-call-manager/internal/tasks"
+	"github.com/dathan/go-grpc-video-call-manager/pkg/manager"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// fakeDaemon answers like the daemon with one meeting open and one scheduled
+type fakeDaemon struct {
+	manager.UnimplementedOpenMeetUrlServer
+	manager.UnimplementedSchedulerServer
+	manager.UnimplementedDispatcherServer
+	left    []string
+	history *manager.HistoryRequest
+}
+
+func (f *fakeDaemon) ListActiveMeetings(context.Context, *emptypb.Empty) (*manager.ActiveMeetings, error) {
+	return &manager.ActiveMeetings{Meetings: []*manager.ActiveMeeting{
+		{SessionId: "abc123", Uri: "https://meet.google.com/frt-ywwd-epk", State: manager.MeetEventType_JOINED, Started: timestamppb.Now()},
+	}}, nil
+}
+
+func (f *fakeDaemon) LeaveMeeting(_ context.Context, req *manager.SessionRequest) (*manager.Status, error) {
+	f.left = append(f.left, req.SessionId)
+	return &manager.Status{Ok: true, SessionId: req.SessionId}, nil
+}
+
+func (f *fakeDaemon) ListSchedule(context.Context, *emptypb.Empty) (*manager.Schedule, error) {
+	start := time.Now().Add(time.Hour)
+	return &manager.Schedule{Tasks: []*manager.ScheduledTask{
+		{Id: "4f2a9c", Name: "standup", Start: timestamppb.New(start), FireTime: timestamppb.New(start.Add(-10 * time.Minute)), AdHoc: true},
+	}}, nil
+}
+
+func (f *fakeDaemon) QueryHistory(_ context.Context, req *manager.HistoryRequest) (*manager.History, error) {
+	f.history = req
+	return &manager.History{Records: []*manager.HistoryRecord{
+		{Time: timestamppb.Now(), Event: "join_failed", TaskId: "4f2a9c", Name: "standup", Outcome: "SESSION_BUSY", Error: "another meeting has the browser"},
+	}}, nil
+}
+
+func (f *fakeDaemon) ListAgents(context.Context, *emptypb.Empty) (*manager.AgentList, error) {
+	return &manager.AgentList{Agents: []*manager.Agent{
+		{Name: "boardroom", Address: "boardroom:50051", Labels: map[string]string{"site": "office", "floor": "2"}, Capabilities: []string{"camera"}, LastSeen: timestamppb.Now()},
+	}}, nil
+}
+
+func (f *fakeDaemon) GetCalendarPoll(context.Context, *emptypb.Empty) (*manager.CalendarPoll, error) {
+	now := time.Now()
+	return &manager.CalendarPoll{Time: timestamppb.New(now), From: timestamppb.New(now), To: timestamppb.New(now.Add(24 * time.Hour)), Events: []*manager.CalendarEvent{
+		{Calendar: "primary", EventId: "standup", Summary: "standup", Start: timestamppb.New(now.Add(time.Hour)), Kept: true, Reason: "invited"},
+		{Calendar: "primary", EventId: "lunch", Summary: "lunch", Start: timestamppb.New(now.Add(2 * time.Hour)), Reason: "no google meet link"},
+	}}, nil
+}
+
+func TestCommands(t *testing.T) {
+	daemon := &fakeDaemon{}
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer()
+	manager.RegisterOpenMeetUrlServer(s, daemon)
+	manager.RegisterSchedulerServer(s, daemon)
+	manager.RegisterDispatcherServer(s, daemon)
+	hs := health.NewServer()
+	hs.SetServingStatus(meettask.HealthBrowser, healthpb.HealthCheckResponse_SERVING)
+	hs.SetServingStatus(meettask.HealthCalendar, healthpb.HealthCheckResponse_NOT_SERVING)
+	healthpb.RegisterHealthServer(s, hs)
+	go s.Serve(lis)
+	defer s.Stop()
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("grpc.Dial() error = %v", err)
+	}
+	defer conn.Close()
+
+	run := func(name string, asJSON bool, args ...string) string {
+		var out bytes.Buffer
+		stdout, jsonOutput = &out, asJSON
+		if err := commands[name].run(context.Background(), conn, args); err != nil {
+			t.Fatalf("meetctl %s error = %v", name, err)
+		}
+		return out.String()
+	}
+
+	if got := run("schedule", false); !strings.Contains(got, "4f2a9c") || !strings.Contains(got, "standup (queued)") {
+		t.Errorf("meetctl schedule = %q, want the queued standup", got)
+	}
+
+	var sched struct {
+		Tasks []struct {
+			ID    string `json:"id"`
+			State string `json:"state"`
+		} `json:"tasks"`
+	}
+	if err := json.Unmarshal([]byte(run("schedule", true)), &sched); err != nil || len(sched.Tasks) != 1 || sched.Tasks[0].State != "PENDING" {
+		t.Errorf("meetctl -json schedule = %+v, %v want the pending standup", sched, err)
+	}
+
+	if got := run("status", false); !strings.Contains(got, "calendar: NOT_SERVING") || !strings.Contains(got, "abc123") {
+		t.Errorf("meetctl status = %q, want the calendar down and the open meeting", got)
+	}
+
+	var st map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(run("status", true)), &st); err != nil || st["meetings"] == nil || st["daemon"] == nil {
+		t.Errorf("meetctl -json status = %s, %v want the health and the meetings", st, err)
+	}
+
+	got := run("history", false, "-from", "-2h")
+	if !strings.Contains(got, "join_failed") || !strings.Contains(got, "standup error: another meeting has the browser") {
+		t.Errorf("meetctl history = %q, want the failed join", got)
+	}
+	if d := daemon.history.To.AsTime().Sub(daemon.history.From.AsTime()); d < 2*time.Hour-time.Second || d > 2*time.Hour+time.Second {
+		t.Errorf("meetctl history -from -2h asked for %s of history", d)
+	}
+
+	if got := run("agents", false); !strings.Contains(got, "boardroom:50051") || !strings.Contains(got, "floor=2,site=office") {
+		t.Errorf("meetctl agents = %q, want the boardroom with its labels", got)
+	}
+
+	if got := run("calendar", false); !strings.Contains(got, "no google meet link") || !strings.Contains(got, "lunch") {
+		t.Errorf("meetctl calendar = %q, want lunch dropped for the missing link", got)
+	}
+
+	// with a single meeting open leave does not need the session
+	if got := run("leave", false); got != "leaving session abc123\n" || len(daemon.left) != 1 {
+		t.Errorf("meetctl leave = %q left %v, want session abc123", got, daemon.left)
+	}
+}
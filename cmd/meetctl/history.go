@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/dathan/go-grpc-video-call-manager/pkg/manager"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// history shows what the scheduler decided and how the joins went
+func history(ctx context.Context, conn *grpc.ClientConn, args []string) error {
+	fs := flag.NewFlagSet("history", flag.ExitOnError)
+	from := fs.String("from", "-24h", "the start of the range, same formats as queue -start")
+	to := fs.String("to", "now", "the end of the range, same formats as queue -start")
+	fs.Parse(args)
+
+	now := time.Now()
+	req := &manager.HistoryRequest{}
+
+	t, err := parseWhen(*from, now)
+	if err != nil {
+		return err
+	}
+	req.From = timestamppb.New(t)
+
+	t, err = parseWhen(*to, now)
+	if err != nil {
+		return err
+	}
+	req.To = timestamppb.New(t)
+
+	h, err := manager.NewSchedulerClient(conn).QueryHistory(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return output(h, func() {
+		w := table()
+		fmt.Fprintln(w, "TIME\tEVENT\tID\tSTART\tJOINED\tATTEMPT\tOUTCOME\tNAME")
+		for _, r := range h.Records {
+			name := r.Name
+			if r.Error != "" {
+				name += " error: " + r.Error
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%d\t%s\t%s\n", when(r.Time), r.Event, r.TaskId, when(r.Scheduled), when(r.Joined), r.Attempt, r.Outcome, name)
+		}
+		w.Flush()
+	})
+}
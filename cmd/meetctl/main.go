@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	meettask "github.com/dathan/go-grpc-video-call-manager/internal/tasks"
+	"github.com/dathan/go-grpc-video-call-manager/internal/utils"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// command is a meetctl sub command
+type command struct {
+	usage string
+	run   func(ctx context.Context, conn *grpc.ClientConn, args []string) error
+}
+
+var commands = map[string]command{
+	"agents":   {"agents  the browser agents registered with the scheduler", listAgents},
+	"calendar": {"calendar  the events the last calendar poll read and why each was kept or dropped", calendarPoll},
+	"history":  {"history [-from WHEN] [-to WHEN]  what the scheduler decided and how the joins went, the last day by default", history},
+	"join":     {"join [-mic] [-camera] [-name NAME] [-windowed] [-prejoin] URI  join a meeting now", join},
+	"queue":    {"queue [-name NAME] [-start WHEN] [-end WHEN] URI  queue a meeting that is not on the calendar", queue},
+	"schedule": {"schedule  list the meetings the daemon will join", schedule},
+	"skip":     {"skip ID  do not join a scheduled meeting", skip},
+	"status":   {"status  health of the daemon and the meetings it has open", showStatus},
+	"leave":    {"leave [SESSION]  leave a meeting, the only one when no session is given", leave},
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: meetctl [-config config.json] [-json] COMMAND [ARGS]\n\ncommands:\n")
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %s\n", commands[name].usage)
+	}
+	fmt.Fprintf(os.Stderr, "\nflags:\n")
+	flag.PrintDefaults()
+}
+
+// drive a running daemon with the same config.json, backend and credentials it uses
+func main() {
+	configFile := flag.String("config", "", "path to config.json, searched for like the daemon does when empty")
+	timeout := flag.Duration("timeout", 30*time.Second, "how long to wait for the daemon")
+	flag.BoolVar(&jsonOutput, "json", false, "print the responses as json")
+	flag.Usage = usage
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		usage()
+		os.Exit(2)
+	}
+
+	cmd, ok := commands[flag.Arg(0)]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "meetctl: unknown command %q\n\n", flag.Arg(0))
+		usage()
+		os.Exit(2)
+	}
+
+	config, err := loadConfig(*configFile)
+	if err != nil {
+		fail(fmt.Errorf("cannot load config: %w", err))
+	}
+
+	conn, err := meettask.Dial(config)
+	if err != nil {
+		fail(fmt.Errorf("cannot connect to %s: %w", config.Backend, err))
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	if err := cmd.run(ctx, conn, flag.Args()[1:]); err != nil {
+		conn.Close()
+		fail(err)
+	}
+}
+
+// loadConfig reads path or looks for config.json where the daemon does
+func loadConfig(path string) (*utils.Config, error) {
+	if path != "" {
+		return utils.LoadConfig([]string{path})
+	}
+
+	paths, err := utils.SearchPaths("config.json")
+	if err != nil {
+		return nil, err
+	}
+	return utils.LoadConfig(paths)
+}
+
+// fail prints the error, with the reason the daemon gave when there is one, and exits
+func fail(err error) {
+	if st, ok := status.FromError(err); ok {
+		err = fmt.Errorf("%s: %s", st.Code(), st.Message())
+		if d := meettask.ErrorDetailOf(st.Err()); d != nil {
+			err = fmt.Errorf("%w (%s retryable: %t)", err, d.Code, d.Retryable)
+		}
+	}
+	fmt.Fprintf(os.Stderr, "meetctl: %v\n", err)
+	os.Exit(1)
+}
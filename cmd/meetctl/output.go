@@ -0,0 +1,64 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// jsonOutput prints the responses as json instead of for people, set by -json
+var jsonOutput bool
+
+// stdout is where the responses go
+var stdout io.Writer = os.Stdout
+
+// output prints msg as json or calls human to print it for people
+func output(msg proto.Message, human func()) error {
+	if !jsonOutput {
+		human()
+		return nil
+	}
+
+	b, err := protojson.MarshalOptions{Multiline: true, EmitUnpopulated: true}.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(stdout, string(b))
+	return nil
+}
+
+// outputJSON prints a json object made of several responses
+func outputJSON(parts map[string]proto.Message) error {
+	obj := map[string]json.RawMessage{}
+	for k, msg := range parts {
+		b, err := protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(msg)
+		if err != nil {
+			return err
+		}
+		obj[k] = b
+	}
+
+	enc := json.NewEncoder(stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(obj)
+}
+
+// table lines up the columns of the human output
+func table() *tabwriter.Writer {
+	return tabwriter.NewWriter(stdout, 0, 4, 2, ' ', 0)
+}
+
+// when formats a timestamp in local time, a dash when it is not set
+func when(ts *timestamppb.Timestamp) string {
+	if ts == nil {
+		return "-"
+	}
+	return ts.AsTime().Local().Format(time.DateTime)
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/dathan/go-grpc-video-call-manager/pkg/manager"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// queue a meeting that is not on the calendar
+func queue(ctx context.Context, conn *grpc.ClientConn, args []string) error {
+	fs := flag.NewFlagSet("queue", flag.ExitOnError)
+	name := fs.String("name", "", "what to call the meeting in the schedule")
+	start := fs.String("start", "now", "when the meeting starts: now, a duration from now (10m), 15:04, 2006-01-02 15:04 or RFC3339")
+	end := fs.String("end", "", "when the meeting ends, same formats as -start, optional")
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		return errors.New("queue takes the meeting link")
+	}
+
+	now := time.Now()
+	req := &manager.QueueRequest{Uri: fs.Arg(0), Name: *name}
+
+	t, err := parseWhen(*start, now)
+	if err != nil {
+		return err
+	}
+	req.Start = timestamppb.New(t)
+
+	if *end != "" {
+		t, err := parseWhen(*end, now)
+		if err != nil {
+			return err
+		}
+		req.End = timestamppb.New(t)
+	}
+
+	task, err := manager.NewSchedulerClient(conn).QueueMeeting(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return output(task, func() {
+		fmt.Fprintf(stdout, "queued %s %s starts %s fires %s\n", task.Id, task.Name, when(task.Start), when(task.FireTime))
+	})
+}
+
+// parseWhen reads a point in time the way people type it on the command line
+func parseWhen(s string, now time.Time) (time.Time, error) {
+	if s == "now" {
+		return now, nil
+	}
+
+	if d, err := time.ParseDuration(s); err == nil {
+		return now.Add(d), nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+
+	if t, err := time.ParseInLocation("2006-01-02 15:04", s, now.Location()); err == nil {
+		return t, nil
+	}
+
+	if t, err := time.ParseInLocation("15:04", s, now.Location()); err == nil {
+		return time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), 0, 0, now.Location()), nil
+	}
+
+	return time.Time{}, fmt.Errorf("cannot read %q as a time", s)
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_parseWhen(t *testing.T) {
+	now := time.Date(2024, 3, 1, 9, 30, 0, 0, time.Local)
+	tests := []struct {
+		in      string
+		want    time.Time
+		wantErr bool
+	}{
+		{"now", now, false},
+		{"10m", now.Add(10 * time.Minute), false},
+		{"15:04", time.Date(2024, 3, 1, 15, 4, 0, 0, time.Local), false},
+		{"2024-03-02 08:00", time.Date(2024, 3, 2, 8, 0, 0, 0, time.Local), false},
+		{"2024-03-02T08:00:00Z", time.Date(2024, 3, 2, 8, 0, 0, 0, time.UTC), false},
+		{"tomorrow", time.Time{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := parseWhen(tt.in, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseWhen(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("parseWhen(%q) = %s, want %s", tt.in, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+// Package metrics holds the prometheus metrics of the daemon and serves them for scraping.
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/sirupsen/logrus"
+)
+
+const namespace = "meet"
+
+// outcomes of a join that are not a failure
+const (
+	OutcomeJoined  = "joined"
+	OutcomePrejoin = "prejoin" // stopped at the pre-join screen as asked
+	OutcomeLeft    = "left"    // left before it was joined
+	OutcomeBusy    = "busy"    // rejected by the concurrency policy
+	OutcomeFailed  = "failed"  // a failure without an error code
+)
+
+var (
+	calendarPolls = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "calendar_polls_total",
+		Help:      "Calendar polls for upcoming meetings.",
+	})
+	calendarPollErrors = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "calendar_poll_errors_total",
+		Help:      "Calendar polls that failed, an empty calendar is not a failure.",
+	})
+	calendarPollDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "calendar_poll_duration_seconds",
+		Help:      "How long a calendar poll took.",
+		Buckets:   prometheus.DefBuckets,
+	})
+
+	joinAttempts = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "join_attempts_total",
+		Help:      "Joins requested from the browser server.",
+	})
+	joinOutcomes = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "join_outcomes_total",
+		Help:      "How the joins ended: joined, prejoin, left, busy or the error code of the failure.",
+	}, []string{"outcome"})
+	joinLateness = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "join_lateness_seconds",
+		Help:      "Seconds between the scheduled start of a meeting and the join, negative when joined early.",
+		Buckets:   []float64{-600, -300, -120, -60, -30, 0, 30, 60, 120, 300, 600, 1200},
+	})
+
+	scheduleMu   sync.Mutex
+	scheduleSize func() int
+	_            = promauto.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "scheduled_tasks",
+		Help:      "Tasks in the cron schedule.",
+	}, func() float64 {
+		scheduleMu.Lock()
+		defer scheduleMu.Unlock()
+		if scheduleSize == nil {
+			return 0
+		}
+		return float64(scheduleSize())
+	})
+)
+
+// CalendarPoll records a poll of the calendar that started at start, err is nil when it worked
+func CalendarPoll(start time.Time, err error) {
+	calendarPolls.Inc()
+	calendarPollDuration.Observe(time.Since(start).Seconds())
+	if err != nil {
+		calendarPollErrors.Inc()
+	}
+}
+
+// WatchSchedule reports the number of scheduled tasks from size
+func WatchSchedule(size func() int) {
+	scheduleMu.Lock()
+	defer scheduleMu.Unlock()
+	scheduleSize = size
+}
+
+// JoinAttempt records a join handed to the browser server
+func JoinAttempt() {
+	joinAttempts.Inc()
+}
+
+// JoinOutcome records how a join ended, failures are recorded by their error code
+func JoinOutcome(outcome string) {
+	if outcome == "" {
+		outcome = OutcomeFailed
+	}
+	joinOutcomes.WithLabelValues(strings.ToLower(outcome)).Inc()
+}
+
+// JoinLateness records when a meeting that was scheduled to start at start was joined
+func JoinLateness(start, joined time.Time) {
+	joinLateness.Observe(joined.Sub(start).Seconds())
+}
+
+// Serve serves /metrics on addr until ctx is done
+func Serve(ctx context.Context, addr string) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	hs := &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		hs.Shutdown(shutdownCtx)
+	}()
+
+	logrus.Infof("Metrics starting %s/metrics", addr)
+	if err := hs.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		logrus.Errorf("could not serve metrics: %v", err)
+	}
+}
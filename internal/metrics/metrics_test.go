@@ -0,0 +1,46 @@
+package metrics
+
+import (
+	"errors"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+func TestMetrics(t *testing.T) {
+	start := time.Now().Add(-time.Second)
+	CalendarPoll(start, nil)
+	CalendarPoll(start, errors.New("token expired"))
+	WatchSchedule(func() int { return 3 })
+	JoinAttempt()
+	JoinOutcome(OutcomeJoined)
+	JoinOutcome("LOGIN_TIMEOUT")
+	JoinOutcome("")
+	JoinLateness(start, start.Add(90*time.Second))
+
+	rec := httptest.NewRecorder()
+	promhttp.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	b, _ := io.ReadAll(rec.Body)
+	got := string(b)
+
+	for _, want := range []string{
+		"meet_calendar_polls_total 2",
+		"meet_calendar_poll_errors_total 1",
+		"meet_calendar_poll_duration_seconds_count 2",
+		"meet_scheduled_tasks 3",
+		"meet_join_attempts_total 1",
+		`meet_join_outcomes_total{outcome="joined"} 1`,
+		`meet_join_outcomes_total{outcome="login_timeout"} 1`,
+		`meet_join_outcomes_total{outcome="failed"} 1`,
+		`meet_join_lateness_seconds_bucket{le="60"} 0`,
+		`meet_join_lateness_seconds_bucket{le="120"} 1`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("/metrics is missing %q", want)
+		}
+	}
+}
@@ -0,0 +1,207 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/dathan/go-grpc-video-call-manager/internal/utils"
+	"github.com/dathan/go-grpc-video-call-manager/pkg/manager"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// agentTTL is how long the scheduler knows an agent without it registering again
+const agentTTL = 30 * time.Second
+
+// agentTimeout is how long an agent has to answer a join before the next one is tried
+const agentTimeout = 15 * time.Second
+
+// ErrNoAgent is returned when a meeting is routed to agents and none of them is registered
+var ErrNoAgent = errors.New("no agent is registered for the meeting")
+
+// agentPool keeps the agents that registered within the ttl
+type agentPool struct {
+	mu     sync.Mutex
+	agents map[string]*manager.Agent
+}
+
+// agents are the browser agents registered with this scheduler
+var agents = newAgentPool()
+
+func newAgentPool() *agentPool {
+	return &agentPool{agents: map[string]*manager.Agent{}}
+}
+
+// register adds or refreshes the agent, true when it was not known
+func (p *agentPool) register(a *manager.Agent) bool {
+	a.LastSeen = timestamppb.Now()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	_, known := p.agents[a.Name]
+	p.agents[a.Name] = a
+	return !known
+}
+
+// live returns the agents seen within the ttl by name, the others are forgotten
+func (p *agentPool) live() []*manager.Agent {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	ret := make([]*manager.Agent, 0, len(p.agents))
+	for name, a := range p.agents {
+		if time.Since(a.LastSeen.AsTime()) > agentTTL {
+			logrus.Warnf("Agent %s at %s stopped registering, forgetting it", name, a.Address)
+			delete(p.agents, name)
+			continue
+		}
+		ret = append(ret, a)
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].Name < ret[j].Name })
+	return ret
+}
+
+// route returns the live agents that can join a meeting on r in the order they are tried
+func (p *agentPool) route(r *utils.Route) []*manager.Agent {
+	var fit []*manager.Agent
+	for _, a := range p.live() {
+		if hasLabels(a, r.Labels) && hasCapabilities(a, r.Capabilities) {
+			fit = append(fit, a)
+		}
+	}
+
+	if len(r.Agents) == 0 {
+		return fit
+	}
+
+	var ret []*manager.Agent
+	for _, name := range r.Agents {
+		for _, a := range fit {
+			if a.Name == name {
+				ret = append(ret, a)
+			}
+		}
+	}
+	return ret
+}
+
+func hasLabels(a *manager.Agent, labels map[string]string) bool {
+	for k, v := range labels {
+		if a.Labels[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+func hasCapabilities(a *manager.Agent, capabilities []string) bool {
+	for _, want := range capabilities {
+		found := false
+		for _, c := range a.Capabilities {
+			if c == want {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// acceptedError is a join that failed after the agent took it, the agent may still be joining
+type acceptedError struct{ error }
+
+func (e acceptedError) Unwrap() error { return e.error }
+
+// failover is true when the join may work on another agent: the agent could not be dialed, did not answer
+// within agentTimeout, is down or is busy with another meeting. Once an agent sent an event it has the join,
+// another agent would join the same meeting twice.
+func failover(err error) bool {
+	if err == nil || errors.As(err, &acceptedError{}) {
+		return false
+	}
+	if d := ErrorDetailOf(err); d != nil {
+		return d.Code == manager.ErrorCode_SESSION_BUSY || status.Code(err) == codes.Unavailable
+	}
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return true
+	}
+	return false
+}
+
+// RegisterAgent adds the agent to the ones meetings are routed to, it has to register again within the ttl
+func (s server) RegisterAgent(c context.Context, a *manager.Agent) (*manager.AgentLease, error) {
+	if a.Name == "" || a.Address == "" {
+		return nil, status.Error(codes.InvalidArgument, "an agent needs a name and an address")
+	}
+
+	if agents.register(a) {
+		logrus.Infof("Agent %s registered at %s labels: %v capabilities: %v", a.Name, a.Address, a.Labels, a.Capabilities)
+	}
+	return &manager.AgentLease{Ttl: durationpb.New(agentTTL)}, nil
+}
+
+// ListAgents returns the registered agents by name
+func (s server) ListAgents(c context.Context, _ *emptypb.Empty) (*manager.AgentList, error) {
+	return &manager.AgentList{Agents: agents.live()}, nil
+}
+
+// RegisterWithScheduler keeps this daemon registered as an agent of the scheduler in config.Agent until ctx is done
+func RegisterWithScheduler(ctx context.Context, config *utils.Config) error {
+	ac := config.Agent
+	if ac.Scheduler == "" || ac.Advertise == "" {
+		return errors.New("an agent needs the scheduler and the address to advertise")
+	}
+
+	agent := &manager.Agent{
+		Name:         ac.Name,
+		Address:      ac.Advertise,
+		Labels:       ac.Labels,
+		Capabilities: ac.Capabilities,
+	}
+	if agent.Name == "" {
+		host, err := os.Hostname()
+		if err != nil {
+			return fmt.Errorf("agent has no name: %w", err)
+		}
+		agent.Name = host
+	}
+
+	// the scheduler is dialed like a backend, with the same tls and token
+	sc := *config
+	sc.Backend = ac.Scheduler
+	conn, err := Dial(&sc)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client := manager.NewDispatcherClient(conn)
+	every := agentTTL / 3
+	for {
+		lease, err := client.RegisterAgent(ctx, agent)
+		if err != nil {
+			logrus.Warnf("Could not register agent %s with the scheduler %s: %v", agent.Name, ac.Scheduler, err)
+		} else if lease.Ttl != nil {
+			every = lease.Ttl.AsDuration() / 3
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(every):
+		}
+	}
+}
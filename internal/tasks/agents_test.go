@@ -0,0 +1,216 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dathan/go-grpc-video-call-manager/internal/utils"
+	"github.com/dathan/go-grpc-video-call-manager/pkg/calendar"
+	"github.com/dathan/go-grpc-video-call-manager/pkg/manager"
+	"github.com/dathan/go-grpc-video-call-manager/pkg/session"
+	"github.com/dathan/go-grpc-video-call-manager/pkg/tasks"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// fakeAgent answers joins the way it is told: joined, busy, failed after taking the join (login, launch) or not at all
+type fakeAgent struct {
+	manager.UnimplementedOpenMeetUrlServer
+	answer string
+	joins  int
+}
+
+func (f *fakeAgent) OpenMeetUrlEvents(man *manager.Meet, stream manager.OpenMeetUrl_OpenMeetUrlEventsServer) error {
+	f.joins++
+	switch f.answer {
+	case "busy":
+		return statusError(ErrSessionBusy)
+	case "hang":
+		<-stream.Context().Done()
+		return stream.Context().Err()
+	case "login", "launch":
+		if err := stream.Send(&manager.MeetEvent{Type: manager.MeetEventType_LAUNCHING_BROWSER, Uri: man.Uri, Time: timestamppb.Now()}); err != nil {
+			return err
+		}
+		failure := map[string]error{"login": session.ErrLoginTimeout, "launch": session.ErrBrowserLaunch}[f.answer]
+		return sendEvent(stream, &manager.MeetEvent{Type: manager.MeetEventType_ERROR, Uri: man.Uri, Time: timestamppb.Now(), Error: errorDetail(failure)})
+	}
+	return stream.Send(&manager.MeetEvent{Type: manager.MeetEventType_JOINED, Uri: man.Uri, Time: timestamppb.Now()})
+}
+
+// serveAgent runs the agent on a unix socket and returns its address
+func serveAgent(t *testing.T, f *fakeAgent) string {
+	path := filepath.Join(t.TempDir(), "agent.sock")
+	lis, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatalf("net.Listen() error = %v", err)
+	}
+	s := grpc.NewServer()
+	manager.RegisterOpenMeetUrlServer(s, f)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+	return unixScheme + path
+}
+
+// useAgents gives the test a pool of its own
+func useAgents(t *testing.T) {
+	prev := agents
+	agents = newAgentPool()
+	t.Cleanup(func() { agents = prev })
+}
+
+func Test_agentPool_route(t *testing.T) {
+	useAgents(t)
+	for _, a := range []*manager.Agent{
+		{Name: "laptop", Address: "laptop:50051", Labels: map[string]string{"owner": "dathan"}},
+		{Name: "boardroom", Address: "boardroom:50051", Labels: map[string]string{"site": "office"}, Capabilities: []string{"camera", "room-display"}},
+		{Name: "huddle", Address: "huddle:50051", Labels: map[string]string{"site": "office"}, Capabilities: []string{"camera"}},
+		{Name: "gone", Address: "gone:50051", Labels: map[string]string{"site": "office"}},
+	} {
+		agents.register(a)
+	}
+	agents.agents["gone"].LastSeen = timestamppb.New(time.Now().Add(-2 * agentTTL))
+
+	tests := []struct {
+		name  string
+		route utils.Route
+		want  []string
+	}{
+		{"everyone", utils.Route{}, []string{"boardroom", "huddle", "laptop"}},
+		{"labels", utils.Route{Labels: map[string]string{"site": "office"}}, []string{"boardroom", "huddle"}},
+		{"capabilities", utils.Route{Capabilities: []string{"room-display"}}, []string{"boardroom"}},
+		{"names in order", utils.Route{Agents: []string{"laptop", "gone", "boardroom"}}, []string{"laptop", "boardroom"}},
+		{"names and labels", utils.Route{Agents: []string{"laptop", "huddle"}, Labels: map[string]string{"site": "office"}}, []string{"huddle"}},
+		{"nobody", utils.Route{Labels: map[string]string{"site": "home"}}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, a := range agents.route(&tt.route) {
+				got = append(got, a.Name)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("route() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("route() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+
+	if _, ok := agents.agents["gone"]; ok {
+		t.Errorf("an agent past its ttl should be forgotten")
+	}
+}
+
+func Test_server_RegisterAgent(t *testing.T) {
+	useAgents(t)
+	s := newServer(nil, PolicyReject)
+
+	if _, err := s.RegisterAgent(context.Background(), &manager.Agent{Name: "laptop"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("RegisterAgent() without an address code = %s, want %s", status.Code(err), codes.InvalidArgument)
+	}
+
+	lease, err := s.RegisterAgent(context.Background(), &manager.Agent{Name: "laptop", Address: "laptop:50051"})
+	if err != nil || lease.Ttl.AsDuration() != agentTTL {
+		t.Fatalf("RegisterAgent() = %v, %v want a %s lease", lease, err, agentTTL)
+	}
+
+	list, _ := s.ListAgents(context.Background(), &emptypb.Empty{})
+	if len(list.Agents) != 1 || list.Agents[0].LastSeen == nil {
+		t.Errorf("ListAgents() = %v, want the laptop", list.Agents)
+	}
+}
+
+func TestMeetTaskImpl_failover(t *testing.T) {
+	useAgents(t)
+	busy, joins := &fakeAgent{answer: "busy"}, &fakeAgent{}
+	agents.register(&manager.Agent{Name: "down", Address: unixScheme + filepath.Join(t.TempDir(), "nobody.sock")})
+	agents.register(&manager.Agent{Name: "busy", Address: serveAgent(t, busy)})
+	agents.register(&manager.Agent{Name: "joins", Address: serveAgent(t, joins)})
+
+	config := &utils.Config{Routes: []utils.Route{
+		{Room: "Boardroom", Agents: []string{"down", "busy", "joins"}},
+		{Match: "offsite"},
+		{Match: "nobody", Agents: []string{"down"}},
+	}}
+	m := &MeetTaskImpl{calendar.MeetItem{Uri: "https://meet.google.com/frt-ywwd-epk", Summary: "standup", StartTime: time.Now(), Rooms: []string{"Boardroom"}}}
+
+	if err := m.execute(context.Background(), config); err != nil {
+		t.Fatalf("execute() error = %v, want the third agent to join", err)
+	}
+	if busy.joins != 1 || joins.joins != 1 {
+		t.Errorf("joins busy: %d joins: %d, want one each", busy.joins, joins.joins)
+	}
+
+	// the agent that is down answers nothing, the join can work once another registers
+	m.Rooms, m.Summary = nil, "nobody"
+	if err := m.execute(context.Background(), config); !errors.Is(err, tasks.ErrRetryable) || status.Code(err) != codes.Unavailable {
+		t.Errorf("execute() with the only agent down error = %v, want a retryable Unavailable", err)
+	}
+
+	m.Summary = "offsite"
+	if err := m.execute(context.Background(), &utils.Config{Routes: []utils.Route{{Labels: map[string]string{"site": "offsite"}}}}); !errors.Is(err, ErrNoAgent) || !errors.Is(err, tasks.ErrRetryable) {
+		t.Errorf("execute() with no agent for the route error = %v, want a retryable %v", err, ErrNoAgent)
+	}
+}
+
+func TestMeetTaskImpl_failover_accepted(t *testing.T) {
+	// the first agent took the join and failed after, another agent would join the meeting again
+	for answer, want := range map[string]manager.ErrorCode{
+		"login":  manager.ErrorCode_LOGIN_TIMEOUT,
+		"launch": manager.ErrorCode_BROWSER_LAUNCH_FAILED,
+	} {
+		t.Run(answer, func(t *testing.T) {
+			useAgents(t)
+			first, joins := &fakeAgent{answer: answer}, &fakeAgent{}
+			agents.register(&manager.Agent{Name: "first", Address: serveAgent(t, first)})
+			agents.register(&manager.Agent{Name: "joins", Address: serveAgent(t, joins)})
+
+			config := &utils.Config{Routes: []utils.Route{{Match: "standup", Agents: []string{"first", "joins"}}}}
+			m := &MeetTaskImpl{calendar.MeetItem{Uri: "https://meet.google.com/frt-ywwd-epk", Summary: "standup", StartTime: time.Now()}}
+
+			err := m.execute(context.Background(), config)
+			if d := ErrorDetailOf(err); d == nil || d.Code != want {
+				t.Errorf("execute() error = %v, want the %s of the first agent", err, want)
+			}
+			if first.joins != 1 || joins.joins != 0 {
+				t.Errorf("joins first: %d second: %d, want the first agent only", first.joins, joins.joins)
+			}
+		})
+	}
+}
+
+func TestMeetTaskImpl_join_noAnswer(t *testing.T) {
+	hang := &fakeAgent{answer: "hang"}
+	config := &utils.Config{Backend: serveAgent(t, hang)}
+	m := &MeetTaskImpl{calendar.MeetItem{Uri: "https://meet.google.com/frt-ywwd-epk", Summary: "standup", StartTime: time.Now()}}
+
+	start := time.Now()
+	err := m.join(context.Background(), config, 100*time.Millisecond)
+	if status.Code(err) != codes.DeadlineExceeded || !failover(err) {
+		t.Errorf("join() of a hung agent error = %v, want DeadlineExceeded to fail over", err)
+	}
+	if time.Since(start) > 5*time.Second {
+		t.Errorf("join() took %s to give up on the hung agent", time.Since(start))
+	}
+}
+
+func TestMeetTaskImpl_join_dialError(t *testing.T) {
+	config := &utils.Config{Backend: "localhost:50051", TLS: &utils.TLSConfig{CAFile: filepath.Join(t.TempDir(), "rotated-ca.pem")}}
+	m := &MeetTaskImpl{calendar.MeetItem{Uri: "https://meet.google.com/frt-ywwd-epk", Summary: "standup", StartTime: time.Now()}}
+
+	err := m.join(context.Background(), config, 0)
+	if !errors.Is(err, tasks.ErrRetryable) || status.Code(err) != codes.Unavailable || !failover(err) {
+		t.Errorf("join() with a missing ca file error = %v, want a retryable Unavailable to fail over", err)
+	}
+}
@@ -0,0 +1,71 @@
+package tasks
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/dathan/go-grpc-video-call-manager/internal/utils"
+	"github.com/dathan/go-grpc-video-call-manager/pkg/manager"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func Test_tokenAuth(t *testing.T) {
+	opts, err := serverOptions(&utils.Config{AuthToken: "s3cret"})
+	if err != nil {
+		t.Fatalf("serverOptions() error = %v", err)
+	}
+
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer(opts...)
+	manager.RegisterOpenMeetUrlServer(s, newServer(nil, PolicyReject))
+	healthpb.RegisterHealthServer(s, newHealthState().srv)
+	go s.Serve(lis)
+	defer s.Stop()
+
+	dial := func(extra ...grpc.DialOption) *grpc.ClientConn {
+		opts := append([]grpc.DialOption{
+			grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+		}, extra...)
+		conn, err := grpc.Dial("bufnet", opts...)
+		if err != nil {
+			t.Fatalf("grpc.Dial() error = %v", err)
+		}
+		return conn
+	}
+
+	tests := []struct {
+		name  string
+		creds grpc.DialOption
+		want  codes.Code
+	}{
+		{"no token", grpc.EmptyDialOption{}, codes.Unauthenticated},
+		{"wrong token", grpc.WithPerRPCCredentials(tokenCredentials{token: "guess"}), codes.Unauthenticated},
+		{"token", grpc.WithPerRPCCredentials(tokenCredentials{token: "s3cret"}), codes.OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn := dial(tt.creds)
+			defer conn.Close()
+
+			_, err := manager.NewOpenMeetUrlClient(conn).ListActiveMeetings(context.Background(), &emptypb.Empty{})
+			if got := status.Code(err); got != tt.want {
+				t.Errorf("ListActiveMeetings() code = %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	// probes do not need the secret
+	conn := dial()
+	defer conn.Close()
+	if _, err := healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{}); err != nil {
+		t.Errorf("health check without a token error = %v", err)
+	}
+}
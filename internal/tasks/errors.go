@@ -0,0 +1,75 @@
+package tasks
+
+import (
+	"errors"
+
+	"github.com/dathan/go-grpc-video-call-manager/pkg/manager"
+	"github.com/dathan/go-grpc-video-call-manager/pkg/session"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// joinFailure maps a session error to its code on the wire and the grpc status code
+type joinFailure struct {
+	err       error
+	code      manager.ErrorCode
+	grpcCode  codes.Code
+	retryable bool
+}
+
+var joinFailures = []joinFailure{
+	{session.ErrLoginTimeout, manager.ErrorCode_LOGIN_TIMEOUT, codes.DeadlineExceeded, false}, // someone has to log in first
+	{session.ErrJoinButtonNotFound, manager.ErrorCode_JOIN_BUTTON_NOT_FOUND, codes.FailedPrecondition, true},
+	{session.ErrBrowserLaunch, manager.ErrorCode_BROWSER_LAUNCH_FAILED, codes.Unavailable, true},
+	{session.ErrNavigation, manager.ErrorCode_NAVIGATION_FAILED, codes.Unavailable, true},
+	{session.ErrMeetingDenied, manager.ErrorCode_MEETING_DENIED, codes.PermissionDenied, false},
+	{ErrSessionBusy, manager.ErrorCode_SESSION_BUSY, codes.ResourceExhausted, true}, // the meeting in the browser may be over by the next try
+}
+
+// errorDetail describes err for the wire
+func errorDetail(err error) *manager.ErrorDetail {
+	for _, f := range joinFailures {
+		if errors.Is(err, f.err) {
+			return &manager.ErrorDetail{Code: f.code, Message: err.Error(), Retryable: f.retryable}
+		}
+	}
+	return &manager.ErrorDetail{Code: manager.ErrorCode_ERROR_CODE_UNSPECIFIED, Message: err.Error()}
+}
+
+// detailError builds the grpc status error carrying the detail
+func detailError(d *manager.ErrorDetail) error {
+	code := codes.Internal
+	for _, f := range joinFailures {
+		if f.code == d.Code {
+			code = f.grpcCode
+		}
+	}
+
+	st, err := status.New(code, d.Message).WithDetails(d)
+	if err != nil {
+		return status.Error(code, d.Message)
+	}
+	return st.Err()
+}
+
+// statusError converts a session error to a grpc status error with its detail
+func statusError(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	return detailError(errorDetail(err))
+}
+
+// ErrorDetailOf returns the detail a server error carries, nil when there is none
+func ErrorDetailOf(err error) *manager.ErrorDetail {
+	st, ok := status.FromError(err)
+	if !ok {
+		return nil
+	}
+	for _, d := range st.Details() {
+		if detail, ok := d.(*manager.ErrorDetail); ok {
+			return detail
+		}
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package tasks
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/dathan/go-grpc-video-call-manager/pkg/manager"
+	"github.com/dathan/go-grpc-video-call-manager/pkg/session"
+	"github.com/dathan/go-grpc-video-call-manager/pkg/tasks"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func Test_statusError(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		code      manager.ErrorCode
+		grpcCode  codes.Code
+		retryable bool
+	}{
+		{"login", session.ErrLoginTimeout, manager.ErrorCode_LOGIN_TIMEOUT, codes.DeadlineExceeded, false},
+		{"button", fmt.Errorf("%w: context deadline exceeded", session.ErrJoinButtonNotFound), manager.ErrorCode_JOIN_BUTTON_NOT_FOUND, codes.FailedPrecondition, true},
+		{"launch", fmt.Errorf("%w: exec: \"google-chrome\": executable file not found in $PATH", session.ErrBrowserLaunch), manager.ErrorCode_BROWSER_LAUNCH_FAILED, codes.Unavailable, true},
+		{"navigation", fmt.Errorf("%w: net::ERR_NAME_NOT_RESOLVED", session.ErrNavigation), manager.ErrorCode_NAVIGATION_FAILED, codes.Unavailable, true},
+		{"denied", session.ErrMeetingDenied, manager.ErrorCode_MEETING_DENIED, codes.PermissionDenied, false},
+		{"unknown", errors.New("boom"), manager.ErrorCode_ERROR_CODE_UNSPECIFIED, codes.Internal, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := statusError(tt.err)
+			if got := status.Code(err); got != tt.grpcCode {
+				t.Errorf("statusError() code = %s, want %s", got, tt.grpcCode)
+			}
+
+			d := ErrorDetailOf(err)
+			if d == nil {
+				t.Fatalf("statusError() carries no ErrorDetail")
+			}
+			if d.Code != tt.code || d.Retryable != tt.retryable || d.Message != tt.err.Error() {
+				t.Errorf("ErrorDetailOf() = %v, want %s retryable %t", d, tt.code, tt.retryable)
+			}
+
+			if got := errors.Is(joinError(err), tasks.ErrRetryable); got != tt.retryable {
+				t.Errorf("joinError() retryable = %t, want %t", got, tt.retryable)
+			}
+		})
+	}
+}
@@ -0,0 +1,256 @@
+package tasks
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/dathan/go-grpc-video-call-manager/internal/utils"
+	"github.com/dathan/go-grpc-video-call-manager/pkg/manager"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// gateway serves the grpc methods as http/json for tools that cannot speak grpc.
+// Every route calls the same server the grpc api uses.
+//
+//	POST   /v1/meetings               {"uri": "..."} open a meeting
+//	GET    /v1/meetings               list the active meetings
+//	DELETE /v1/meetings/{session_id}  leave a meeting
+//	GET    /v1/schedule               list the schedule
+//	POST   /v1/schedule               {"uri": "...", "start": "..."} queue a meeting that is not on the calendar
+//	GET    /v1/schedule/next          the next task to run
+//	POST   /v1/schedule/{id}/skip     skip, snooze {"duration": "300s"} from the launch, force or clear a task
+//	GET    /v1/history?from=&to=      the history between two RFC3339 times, open ended when left out
+//	GET    /v1/calendar               the events of the last calendar poll and why each was kept or dropped
+//	GET    /v1/status?service=browser health of the daemon or one subsystem
+type gateway struct {
+	srv  server
+	auth *tokenAuth // nil when no token is configured
+}
+
+func newGateway(srv server, token string) *gateway {
+	g := &gateway{srv: srv}
+	if token != "" {
+		g.auth = &tokenAuth{token: token}
+	}
+	return g
+}
+
+// ServeHTTP routes the request, the status check stays open like the grpc health service so probes do not
+// need the secret
+func (g *gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	open := strings.Trim(r.URL.Path, "/") == "v1/status" && r.Method == http.MethodGet
+	if g.auth != nil && !open && !g.auth.valid(bearerToken(r.Header.Get("Authorization")), r.Method+" "+r.URL.Path, r.RemoteAddr) {
+		writeError(w, status.Error(codes.Unauthenticated, "missing or invalid bearer token"))
+		return
+	}
+
+	ctx := r.Context()
+	path := strings.Trim(r.URL.Path, "/")
+	parts := strings.Split(path, "/")
+
+	switch {
+	case path == "v1/meetings" && r.Method == http.MethodPost:
+		man := &manager.Meet{}
+		if err := readProto(r, man); err != nil {
+			writeError(w, err)
+			return
+		}
+		respond(w)(g.srv.OpenMeetUrl(ctx, man))
+
+	case path == "v1/meetings" && r.Method == http.MethodGet:
+		respond(w)(g.srv.ListActiveMeetings(ctx, &emptypb.Empty{}))
+
+	case len(parts) == 3 && parts[0] == "v1" && parts[1] == "meetings" && r.Method == http.MethodDelete:
+		respond(w)(g.srv.LeaveMeeting(ctx, &manager.SessionRequest{SessionId: parts[2]}))
+
+	case path == "v1/schedule" && r.Method == http.MethodGet:
+		respond(w)(g.srv.ListSchedule(ctx, &emptypb.Empty{}))
+
+	case path == "v1/schedule" && r.Method == http.MethodPost:
+		req := &manager.QueueRequest{}
+		if err := readProto(r, req); err != nil {
+			writeError(w, err)
+			return
+		}
+		respond(w)(g.srv.QueueMeeting(ctx, req))
+
+	case path == "v1/schedule/next" && r.Method == http.MethodGet:
+		respond(w)(g.srv.GetNextTask(ctx, &emptypb.Empty{}))
+
+	case len(parts) == 4 && parts[0] == "v1" && parts[1] == "schedule" && r.Method == http.MethodPost:
+		g.override(w, r, parts[2], parts[3])
+
+	case path == "v1/history" && r.Method == http.MethodGet:
+		req := &manager.HistoryRequest{}
+		for name, ts := range map[string]**timestamppb.Timestamp{"from": &req.From, "to": &req.To} {
+			v := r.URL.Query().Get(name)
+			if v == "" {
+				continue
+			}
+			t, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				writeError(w, status.Errorf(codes.InvalidArgument, "%s: %v", name, err))
+				return
+			}
+			*ts = timestamppb.New(t)
+		}
+		respond(w)(g.srv.QueryHistory(ctx, req))
+
+	case path == "v1/calendar" && r.Method == http.MethodGet:
+		respond(w)(g.srv.GetCalendarPoll(ctx, &emptypb.Empty{}))
+
+	case path == "v1/status" && r.Method == http.MethodGet:
+		respond(w)(daemonHealth.srv.Check(ctx, &healthpb.HealthCheckRequest{Service: r.URL.Query().Get("service")}))
+
+	default:
+		writeError(w, status.Errorf(codes.NotFound, "no route for %s %s", r.Method, r.URL.Path))
+	}
+}
+
+// override maps /v1/schedule/{id}/{action} to the scheduler overrides
+func (g *gateway) override(w http.ResponseWriter, r *http.Request, id, action string) {
+	ctx := r.Context()
+	req := &manager.TaskRequest{Id: id}
+
+	switch action {
+	case "skip":
+		respond(w)(g.srv.SkipTask(ctx, req))
+	case "force":
+		respond(w)(g.srv.ForceJoinTask(ctx, req))
+	case "clear":
+		respond(w)(g.srv.ClearTaskOverride(ctx, req))
+	case "snooze":
+		snooze := &manager.SnoozeRequest{}
+		if err := readProto(r, snooze); err != nil {
+			writeError(w, err)
+			return
+		}
+		snooze.Id = id
+		respond(w)(g.srv.SnoozeTask(ctx, snooze))
+	default:
+		writeError(w, status.Errorf(codes.NotFound, "unknown action %s", action))
+	}
+}
+
+// readProto decodes the json body into msg, an empty body leaves msg empty
+func readProto(r *http.Request, msg proto.Message) error {
+	b, err := io.ReadAll(io.LimitReader(r.Body, 1<<20))
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if len(b) == 0 {
+		return nil
+	}
+	if err := protojson.Unmarshal(b, msg); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return nil
+}
+
+// respond returns a func that writes the result of a server method as json
+func respond(w http.ResponseWriter) func(proto.Message, error) {
+	return func(msg proto.Message, err error) {
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		b, err := protojson.Marshal(msg)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(b)
+	}
+}
+
+// the http status for each grpc code the server returns
+var httpStatus = map[codes.Code]int{
+	codes.InvalidArgument:    http.StatusBadRequest,
+	codes.Unauthenticated:    http.StatusUnauthorized,
+	codes.PermissionDenied:   http.StatusForbidden,
+	codes.NotFound:           http.StatusNotFound,
+	codes.AlreadyExists:      http.StatusConflict,
+	codes.FailedPrecondition: http.StatusPreconditionFailed,
+	codes.ResourceExhausted:  http.StatusTooManyRequests,
+	codes.Unavailable:        http.StatusServiceUnavailable,
+	codes.DeadlineExceeded:   http.StatusGatewayTimeout,
+}
+
+// writeError writes the grpc status of err as json
+func writeError(w http.ResponseWriter, err error) {
+	st := status.Convert(err)
+	code, ok := httpStatus[st.Code()]
+	if !ok {
+		code = http.StatusInternalServerError
+	}
+
+	body := map[string]interface{}{
+		"code":    st.Code().String(),
+		"message": st.Message(),
+	}
+	if d := ErrorDetailOf(err); d != nil {
+		body["error_code"] = d.Code.String()
+		body["retryable"] = d.Retryable
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(body)
+}
+
+// serveGateway serves the http/json api next to the grpc server until ctx is done
+func serveGateway(ctx context.Context, config *utils.Config, srv server) {
+	token, err := config.Token()
+	if err != nil {
+		logrus.Errorf("could not read the auth token for the http gateway: %v", err)
+		return
+	}
+
+	hs := &http.Server{
+		Addr:              config.HTTPListen,
+		Handler:           newGateway(srv, token),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+		logrus.Infoln("HTTP gateway is shutting down")
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		hs.Shutdown(shutdownCtx)
+	}()
+
+	logrus.Infof("HTTP gateway starting %s tls: %t", config.HTTPListen, config.TLS != nil)
+	if config.TLS != nil {
+		var c *tls.Config
+		c, err = utils.ServerTLSConfig(config.TLS)
+		if err != nil {
+			logrus.Errorf("could not configure tls for the http gateway: %v", err)
+			return
+		}
+		hs.TLSConfig = c
+		err = hs.ListenAndServeTLS("", "")
+	} else {
+		err = hs.ListenAndServe()
+	}
+
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		logrus.Errorf("could not serve http: %v", err)
+	}
+}
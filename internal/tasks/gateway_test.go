@@ -0,0 +1,135 @@
+package tasks
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dathan/go-grpc-video-call-manager/internal/utils"
+	"github.com/dathan/go-grpc-video-call-manager/pkg/calendar"
+	"github.com/dathan/go-grpc-video-call-manager/pkg/tasks"
+)
+
+func Test_gateway(t *testing.T) {
+	task := &MeetTaskImpl{calendar.MeetItem{
+		Uri:       "https://meet.google.com/frt-ywwd-epk",
+		Summary:   "standup",
+		StartTime: time.Now().Add(time.Hour),
+		EndTime:   time.Now().Add(90 * time.Minute),
+	}}
+	cron := tasks.NewCron(context.Background(), tasks.SequentialTasks{task}, &utils.Config{})
+	ts := httptest.NewServer(newGateway(newServer(cron, PolicyReject), "s3cret"))
+	defer ts.Close()
+
+	do := func(method, path, body, token string) (int, map[string]interface{}) {
+		req, err := http.NewRequest(method, ts.URL+path, strings.NewReader(body))
+		if err != nil {
+			t.Fatalf("http.NewRequest() error = %v", err)
+		}
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("%s %s error = %v", method, path, err)
+		}
+		defer resp.Body.Close()
+
+		got := map[string]interface{}{}
+		json.NewDecoder(resp.Body).Decode(&got)
+		return resp.StatusCode, got
+	}
+
+	if code, _ := do("GET", "/v1/meetings", "", ""); code != http.StatusUnauthorized {
+		t.Errorf("GET /v1/meetings without a token = %d, want %d", code, http.StatusUnauthorized)
+	}
+
+	// load balancers and liveness probes check the status without the secret, like the grpc health service
+	code, got := do("GET", "/v1/status", "", "")
+	if code != http.StatusOK || got["status"] != "SERVING" {
+		t.Errorf("GET /v1/status without a token = %d %v, want SERVING", code, got)
+	}
+
+	// the grpc api takes a bearer token only, so does the gateway
+	raw, _ := http.NewRequest("GET", ts.URL+"/v1/meetings", nil)
+	raw.Header.Set("Authorization", "s3cret")
+	resp, err := http.DefaultClient.Do(raw)
+	if err != nil {
+		t.Fatalf("GET /v1/meetings with a raw token error = %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("GET /v1/meetings with a raw token = %d, want %d", resp.StatusCode, http.StatusUnauthorized)
+	}
+
+	if code, _ := do("GET", "/v1/meetings", "", "s3cret"); code != http.StatusOK {
+		t.Errorf("GET /v1/meetings = %d, want %d", code, http.StatusOK)
+	}
+
+	code, got = do("GET", "/v1/schedule/next", "", "s3cret")
+	if code != http.StatusOK || got["uri"] != task.Uri {
+		t.Errorf("GET /v1/schedule/next = %d %v, want the standup", code, got)
+	}
+
+	id := tasks.TaskID(task)
+	code, got = do("POST", "/v1/schedule/"+id+"/snooze", `{"duration": "300s"}`, "s3cret")
+	if code != http.StatusOK || got["override"] != "SNOOZE" || got["snooze"] != "300s" {
+		t.Errorf("POST snooze = %d %v, want a 300s snooze", code, got)
+	}
+
+	// launched 20 minutes after the start the meeting would be skipped as too old
+	if code, _ := do("POST", "/v1/schedule/"+id+"/snooze", `{"duration": "1800s"}`, "s3cret"); code != http.StatusBadRequest {
+		t.Errorf("POST snooze of 30 minutes = %d, want %d", code, http.StatusBadRequest)
+	}
+
+	queue := `{"uri": "https://meet.google.com/abc-defg-hij", "name": "pasted in chat", "start": "` + time.Now().Add(2*time.Hour).Format(time.RFC3339) + `"}`
+	code, got = do("POST", "/v1/schedule", queue, "s3cret")
+	if code != http.StatusOK || got["adHoc"] != true || got["name"] != "pasted in chat => [ https://meet.google.com/abc-defg-hij ]" {
+		t.Errorf("POST /v1/schedule = %d %v, want the queued meeting", code, got)
+	}
+
+	if code, _ := do("POST", "/v1/schedule", queue, "s3cret"); code != http.StatusConflict {
+		t.Errorf("POST /v1/schedule of a queued meeting = %d, want %d", code, http.StatusConflict)
+	}
+
+	late := `{"uri": "https://meet.google.com/abc-defg-hij", "start": "` + time.Now().Add(-30*time.Minute).Format(time.RFC3339) + `"}`
+	if code, _ := do("POST", "/v1/schedule", late, "s3cret"); code != http.StatusBadRequest {
+		t.Errorf("POST /v1/schedule of a meeting that started 30 minutes ago = %d, want %d", code, http.StatusBadRequest)
+	}
+
+	if code, _ := do("POST", "/v1/schedule", `{"uri": "not a link", "start": "2024-03-01T10:00:00Z"}`, "s3cret"); code != http.StatusBadRequest {
+		t.Errorf("POST /v1/schedule with a bad uri = %d, want %d", code, http.StatusBadRequest)
+	}
+
+	if code, _ := do("POST", "/v1/schedule/missing/skip", "", "s3cret"); code != http.StatusNotFound {
+		t.Errorf("POST skip of an unknown task = %d, want %d", code, http.StatusNotFound)
+	}
+
+	if code, _ := do("GET", "/v1/history?from=2024-03-01T00:00:00Z", "", "s3cret"); code != http.StatusOK {
+		t.Errorf("GET /v1/history = %d, want %d", code, http.StatusOK)
+	}
+
+	if code, _ := do("GET", "/v1/history?from=yesterday", "", "s3cret"); code != http.StatusBadRequest {
+		t.Errorf("GET /v1/history with a bad from = %d, want %d", code, http.StatusBadRequest)
+	}
+
+	lastPoll.record(time.Now(), time.Now().Add(24*time.Hour), []calendar.Provider{&fakeProvider{decisions: []calendar.Decision{
+		{Calendar: "primary", EventID: "standup", Summary: "standup", Start: task.StartTime, Kept: true, Reason: "invited"},
+	}}})
+	code, got = do("GET", "/v1/calendar", "", "s3cret")
+	if events, _ := got["events"].([]interface{}); code != http.StatusOK || len(events) != 1 {
+		t.Errorf("GET /v1/calendar = %d %v, want the standup", code, got)
+	}
+
+	if code, _ := do("DELETE", "/v1/meetings/missing", "", "s3cret"); code != http.StatusNotFound {
+		t.Errorf("DELETE of an unknown meeting = %d, want %d", code, http.StatusNotFound)
+	}
+
+	if code, _ := do("POST", "/v1/meetings", "{not json", "s3cret"); code != http.StatusBadRequest {
+		t.Errorf("POST /v1/meetings with a bad body = %d, want %d", code, http.StatusBadRequest)
+	}
+}
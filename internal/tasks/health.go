@@ -0,0 +1,109 @@
+package tasks
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/dathan/go-grpc-video-call-manager/pkg/manager"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// subsystems the daemon depends on, they are reported as services of the health check
+const (
+	HealthBrowser  = "browser"
+	HealthCalendar = "calendar"
+)
+
+// the grpc service that stops working when a subsystem is down
+var healthServices = map[string]string{
+	HealthBrowser:  manager.OpenMeetUrl_ServiceDesc.ServiceName,
+	HealthCalendar: manager.Scheduler_ServiceDesc.ServiceName,
+}
+
+// healthState keeps the standard health server in line with the subsystems
+type healthState struct {
+	mu     sync.Mutex
+	srv    *health.Server
+	errors map[string]error
+}
+
+// how often the browser is started while no meeting has it, so a broken one shows before the next meeting
+const BROWSER_PROBE_DELTA = 300 // seconds
+
+// subsystem health is shared by the grpc server, the calendar poll and the browser sessions
+var daemonHealth = newHealthState()
+
+func newHealthState() *healthState {
+	h := &healthState{
+		srv:    health.NewServer(),
+		errors: map[string]error{},
+	}
+	for name := range healthServices {
+		h.set(name, nil)
+	}
+	return h
+}
+
+// set records the result of the last use of the subsystem, nil is healthy
+func (h *healthState) set(subsystem string, err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if prev, ok := h.errors[subsystem]; ok && (prev == nil) != (err == nil) {
+		if err != nil {
+			logrus.Warnf("Health: %s is NOT_SERVING: %v", subsystem, err)
+		} else {
+			logrus.Infof("Health: %s is SERVING", subsystem)
+		}
+	}
+	h.errors[subsystem] = err
+
+	status := healthpb.HealthCheckResponse_SERVING
+	if err != nil {
+		status = healthpb.HealthCheckResponse_NOT_SERVING
+	}
+	h.srv.SetServingStatus(subsystem, status)
+	h.srv.SetServingStatus(healthServices[subsystem], status)
+
+	// the server as a whole is only serving when every subsystem is
+	overall := healthpb.HealthCheckResponse_SERVING
+	for _, e := range h.errors {
+		if e != nil {
+			overall = healthpb.HealthCheckResponse_NOT_SERVING
+		}
+	}
+	h.srv.SetServingStatus("", overall)
+}
+
+// probeBrowser keeps the browser health up to date between meetings until ctx is done
+func (s server) probeBrowser(ctx context.Context, probe func(context.Context) error) {
+	t := time.NewTicker(time.Duration(BROWSER_PROBE_DELTA) * time.Second)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			s.checkBrowser(ctx, probe)
+		}
+	}
+}
+
+// checkBrowser probes the browser unless a meeting has it, a live meeting reports how its own launch went
+func (s server) checkBrowser(ctx context.Context, probe func(context.Context) error) {
+	if len(s.meetings.list()) > 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	err := probe(ctx)
+	if ctx.Err() == context.Canceled {
+		return // shutting down, not broken
+	}
+	daemonHealth.set(HealthBrowser, err)
+}
@@ -0,0 +1,71 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/dathan/go-grpc-video-call-manager/pkg/manager"
+	"github.com/dathan/go-grpc-video-call-manager/pkg/session"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func Test_healthState_set(t *testing.T) {
+	h := newHealthState()
+
+	check := func(service string) healthpb.HealthCheckResponse_ServingStatus {
+		resp, err := h.srv.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+		if err != nil {
+			t.Fatalf("Check(%q) error = %v", service, err)
+		}
+		return resp.Status
+	}
+
+	if got := check(""); got != healthpb.HealthCheckResponse_SERVING {
+		t.Errorf("initial status = %s, want SERVING", got)
+	}
+
+	h.set(HealthCalendar, errors.New("oauth2: token expired and refresh token is not set"))
+	for _, service := range []string{"", HealthCalendar, manager.Scheduler_ServiceDesc.ServiceName} {
+		if got := check(service); got != healthpb.HealthCheckResponse_NOT_SERVING {
+			t.Errorf("status of %q with a broken calendar = %s, want NOT_SERVING", service, got)
+		}
+	}
+	if got := check(HealthBrowser); got != healthpb.HealthCheckResponse_SERVING {
+		t.Errorf("browser status with a broken calendar = %s, want SERVING", got)
+	}
+
+	h.set(HealthCalendar, nil)
+	if got := check(""); got != healthpb.HealthCheckResponse_SERVING {
+		t.Errorf("status after the calendar recovered = %s, want SERVING", got)
+	}
+}
+
+func Test_server_checkBrowser(t *testing.T) {
+	defer daemonHealth.set(HealthBrowser, nil)
+	s := newServer(nil, PolicyReject)
+
+	probes := 0
+	broken := func(context.Context) error {
+		probes++
+		return fmt.Errorf("%w: exec: \"google-chrome\": executable file not found in $PATH", session.ErrBrowserLaunch)
+	}
+
+	m := s.meetings.add("https://meet.google.com/frt-ywwd-epk", &session.Session{})
+	s.checkBrowser(context.Background(), broken)
+	if probes != 0 {
+		t.Errorf("the browser was probed while a meeting had it")
+	}
+
+	s.meetings.remove(m)
+	s.checkBrowser(context.Background(), broken)
+	if probes != 1 || daemonHealth.errors[HealthBrowser] == nil {
+		t.Errorf("a browser that cannot start between meetings is healthy")
+	}
+
+	s.checkBrowser(context.Background(), func(context.Context) error { return nil })
+	if daemonHealth.errors[HealthBrowser] != nil {
+		t.Errorf("a browser that starts again is still unhealthy")
+	}
+}
@@ -4,16 +4,28 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"sort"
 	"time"
 
+	"github.com/dathan/go-grpc-video-call-manager/internal/metrics"
 	"github.com/dathan/go-grpc-video-call-manager/internal/utils"
 	"github.com/dathan/go-grpc-video-call-manager/pkg/calendar"
+	"github.com/dathan/go-grpc-video-call-manager/pkg/history"
 	"github.com/dathan/go-grpc-video-call-manager/pkg/manager"
 	"github.com/dathan/go-grpc-video-call-manager/pkg/tasks"
+	"github.com/google/go-cmp/cmp"
 	"github.com/sirupsen/logrus"
-	"google.golang.org/grpc"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+var tracer = otel.Tracer("github.com/dathan/go-grpc-video-call-manager/internal/tasks")
+
 // support for a magic number in seconds
 const MEETING_FETCH_DELTA = 30
 
@@ -34,6 +46,11 @@ func (m *MeetTaskImpl) Name() string {
 	return m.Summary + " => [ " + m.Uri + " ]"
 }
 
+// URI is the meeting link, the cron records it in the history
+func (m *MeetTaskImpl) URI() string {
+	return m.Uri
+}
+
 // return the start time
 func (m *MeetTaskImpl) Start() time.Time {
 	return m.StartTime
@@ -45,43 +62,225 @@ func (m *MeetTaskImpl) End() time.Time {
 }
 
 // Run the current task from the cron package
-func (m *MeetTaskImpl) Execute(config *utils.Config) error {
+func (m *MeetTaskImpl) Execute(ctx context.Context, config *utils.Config) error {
+	ctx, span := tracer.Start(ctx, "meet.execute", trace.WithAttributes(
+		attribute.String("meet.uri", m.Uri),
+		attribute.String("meet.summary", m.Summary),
+		attribute.String("meet.start", m.Start().Format(time.RFC3339)),
+	))
+	defer span.End()
+
+	err := m.execute(ctx, config)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, err.Error())
+	}
+	return err
+}
 
-	if time.Since(m.Start()).Minutes() > 10.0 { // we start 10 minutes early if the meeting has started already skip
+// execute asks the browser server to join and follows the meeting until it is joined.
+// A routed meeting goes to the agents of its route in turn until one of them takes it.
+func (m *MeetTaskImpl) execute(ctx context.Context, config *utils.Config) error {
+
+	if time.Since(m.Start()).Seconds() > tasks.MAGIC_DELTA { // we start 10 minutes early if the meeting has started already skip
 		logrus.Warnf("MEET TASK IS OLD NEED TO SKIP!! %s", m)
-		return errors.New("Task is too old to run..skipping")
+		return fmt.Errorf("Task is too old to run..skipping: %w", tasks.ErrSkipped)
 	}
 
 	logrus.Infof("Execute !!!: %s => %s\n", m.Summary, m.Uri)
+
+	route := config.RouteFor(m.Calendar, m.Summary, m.Rooms)
+	if route == nil {
+		return m.join(ctx, config, 0)
+	}
+
+	candidates := agents.route(route)
+	if len(candidates) == 0 {
+		err := fmt.Errorf("%w: %s", ErrNoAgent, m.Name())
+		m.record(ctx, history.EventJoinFailed, nil, err)
+		return fmt.Errorf("%w: %w", tasks.ErrRetryable, err) // the agent may register by the next try
+	}
+
+	var err error
+	for _, a := range candidates {
+		logrus.Infof("Routing %s to agent %s at %s", m.Summary, a.Name, a.Address)
+		trace.SpanFromContext(ctx).SetAttributes(attribute.String("meet.agent", a.Name))
+
+		agentConfig := *config
+		agentConfig.Backend = a.Address
+		err = m.join(ctx, &agentConfig, agentTimeout)
+		if !failover(err) {
+			return err
+		}
+		logrus.Warnf("Agent %s did not take %s: %v", a.Name, m.Summary, err)
+	}
+	return err
+}
+
+// join asks the browser server at the backend to join and follows the meeting until it is joined.
+// A server that sends nothing within timeout is given up on, no timeout when it is 0.
+func (m *MeetTaskImpl) join(ctx context.Context, config *utils.Config, timeout time.Duration) error {
 	backend := config.Backend
 
-	conn, err := grpc.Dial(backend, grpc.WithInsecure())
+	// a cert or token file that cannot be read may be fixed by the next try, another agent may do without it
+	conn, err := Dial(config)
 	if err != nil {
-		logrus.Fatalf("could not connect to %s: %v", backend, err)
+		err = status.Errorf(codes.Unavailable, "could not connect to %s: %v", backend, err)
+		logrus.Errorf("EXECUTE ERROR: %s", err.Error())
+		m.record(ctx, history.EventJoinFailed, nil, err)
+		return joinError(err)
 	}
 	defer conn.Close()
 
 	client := manager.NewOpenMeetUrlClient(conn)
+	opts := config.JoinOptionsFor(m.Summary)
 	meet := &manager.Meet{
 		Uri:  m.Uri,
 		Done: false,
+		Options: &manager.JoinOptions{
+			MicOn:         opts.MicOn,
+			CameraOn:      opts.CameraOn,
+			DisplayName:   opts.DisplayName,
+			StayOnPrejoin: opts.StayOnPrejoin,
+		},
+	}
+	if opts.Windowed {
+		meet.Options.WindowMode = manager.WindowMode_WINDOWED
+	}
+
+	// hanging up the stream leaves the meeting running on the server
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// the first event is the answer, a server that does not give one in time is hung up on
+	var answer *time.Timer
+	if timeout > 0 {
+		answer = time.AfterFunc(timeout, cancel)
+	}
+	noAnswer := func(err error) error {
+		if answer == nil {
+			return err
+		}
+		if !answer.Stop() && err != nil {
+			err = status.Errorf(codes.DeadlineExceeded, "no answer from %s within %s", backend, timeout)
+		}
+		answer = nil
+		return err
 	}
 
-	stat, err := client.OpenMeetUrl(context.Background(), meet)
+	stream, err := client.OpenMeetUrlEvents(ctx, meet)
 	if err != nil {
+		err = noAnswer(err)
 		logrus.Errorf("EXECUTE ERROR: %s", err.Error())
+		m.record(ctx, history.EventJoinFailed, nil, err)
+		return joinError(err)
+	}
+
+	// follow the meeting until it is joined, the server keeps it running after that.
+	// the server has the join once it sent an event, a failure after that is not handed to another agent
+	accepted := false
+	failed := func(err error) error {
+		m.record(ctx, history.EventJoinFailed, nil, err)
+		if err = joinError(err); accepted {
+			return acceptedError{err}
+		}
 		return err
 	}
+	for {
+		ev, err := stream.Recv()
+		err = noAnswer(err)
+		if err == io.EOF {
+			return nil
+		}
+
+		if err != nil {
+			logrus.Errorf("EXECUTE ERROR: %s", err.Error())
+			return failed(err)
+		}
+		accepted = true
+
+		logrus.Infof("MEET EVENT: [%s] %s => %s %s", ev.SessionId, ev.Type, ev.Uri, ev.Message)
+		trace.SpanFromContext(ctx).AddEvent(ev.Type.String(), trace.WithAttributes(attribute.String("session.id", ev.SessionId)))
+		switch ev.Type {
+		case manager.MeetEventType_JOINED:
+			metrics.JoinLateness(m.Start(), ev.Time.AsTime())
+			m.record(ctx, history.EventJoined, ev, nil)
+			return nil
+		case manager.MeetEventType_PREJOIN:
+			m.record(ctx, history.EventPrejoin, ev, nil)
+			return nil
+		case manager.MeetEventType_ERROR:
+			logrus.Errorf("Server ERROR: %s", ev.Message)
+			err := errors.New("GRPC SERVER ERROR: " + ev.Message)
+			if ev.Error != nil {
+				err = detailError(ev.Error)
+			}
+			return failed(err)
+		}
+	}
+}
+
+// record appends the result of the join to the history the cron passed along, ev is the event that ended it
+func (m *MeetTaskImpl) record(ctx context.Context, event string, ev *manager.MeetEvent, err error) {
+	r := history.Record{
+		Event:     event,
+		TaskID:    tasks.TaskID(m),
+		Name:      m.Name(),
+		URI:       m.Uri,
+		Scheduled: m.Start(),
+	}
+
+	if ev != nil {
+		joined := ev.Time.AsTime().Local()
+		r.Joined = &joined
+		r.Outcome = ev.Type.String()
+	}
+
+	if err != nil {
+		r.Outcome = status.Code(err).String()
+		if d := ErrorDetailOf(err); d != nil {
+			r.Outcome = d.Code.String()
+		}
+		r.Error = err.Error()
+	}
+
+	if err := history.FromContext(ctx).Append(r); err != nil {
+		logrus.Errorf("Could not record %s of %s: %v", event, m.Name(), err)
+	}
+}
+
+// joinError tells the cron whether the failed join is worth another try
+func joinError(err error) error {
+	retryable := status.Code(err) == codes.Unavailable // the server is not up (yet)
+	if d := ErrorDetailOf(err); d != nil {
+		logrus.Warnf("Join failed: %s retryable: %t", d.Code, d.Retryable)
+		retryable = d.Retryable
+	}
 
-	if stat.ErrorMsg != "" {
-		logrus.Errorf("Server ERROR: %s", stat.ErrorMsg)
-		return errors.New("GRPC SERVER ERROR: " + stat.ErrorMsg)
+	if retryable {
+		return fmt.Errorf("%w: %w", tasks.ErrRetryable, err)
 	}
-	return nil
+	return err
 }
 
-// keep running a timer in a go-routine to look for new meetings
+// keep running a timer in a go-routine to look for new meetings, the providers are kept between the polls
+// so the calendars are synced incrementally
 func UpdateCronMeetings(ctx context.Context, cron *tasks.Cron) {
+	updateCronMeetings(ctx, cron, nil)
+}
+
+// setupProviders builds the calendar providers of the config, the calendar is unhealthy until it works
+func setupProviders(config *utils.Config) ([]calendar.Provider, error) {
+	providers, err := calendar.Providers(config)
+	if err != nil {
+		err = fmt.Errorf("unable to set up the calendar providers: %w", err)
+		daemonHealth.set(HealthCalendar, err)
+		return nil, err
+	}
+	return providers, nil
+}
+
+func updateCronMeetings(ctx context.Context, cron *tasks.Cron, providers []calendar.Provider) {
 	// note cannot use a ticker, since that keeps fireing even if that timer body is blocked?
 	t := time.NewTimer(time.Duration(MEETING_FETCH_DELTA) * time.Second)
 	defer func() {
@@ -90,6 +289,23 @@ func UpdateCronMeetings(ctx context.Context, cron *tasks.Cron) {
 		}
 	}()
 
+	// providers is nil until they could be set up, every poll tries again until then
+	poll := func(ctx context.Context) (calendar.MeetItems, error) {
+		if providers == nil {
+			var err error
+			if providers, err = setupProviders(cron.Config); err != nil {
+				return nil, err
+			}
+		}
+
+		from, to := cron.Config.Window(time.Now())
+		meetings, err := findMeetings(ctx, providers, from, to)
+		if errors.Is(err, calendar.ErrNoUpcomingEvents) {
+			return meetings, nil // the ones on the schedule were cancelled
+		}
+		return meetings, err
+	}
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -97,30 +313,94 @@ func UpdateCronMeetings(ctx context.Context, cron *tasks.Cron) {
 
 		case <-t.C:
 
-			tsks, err := GetTasks(cron.Config)
+			pollCtx, span := tracer.Start(ctx, "calendar.poll")
+			meetings, err := poll(pollCtx)
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(otelcodes.Error, err.Error())
+			}
+			span.SetAttributes(attribute.Int("tasks", len(meetings)))
+			span.End()
+
 			if err != nil {
 				// When I close my laptop for the day, I want the client to recover
 				for { //TODO: 1140 minutes in a day, wait a day -- fix this
 					logrus.Errorf("Error from find meetings - retrying: %s", err)
 					// send a message to reset the channels (laptop is sleep)
-					cron.Update(tasks.SequentialTasks{})
+					cron.Update(pollCtx, tasks.SequentialTasks{})
 					time.Sleep(1 * time.Minute)
-					tsks, err = GetTasks(cron.Config)
+					meetings, err = poll(ctx)
 					if err == nil {
 						break
 					}
 				}
 			}
 
-			// go through all the tasks and skip the meetings that have started
-			// tsks = PruneTasks(tsks)
-			cron.Update(tsks) // this will block and we want that if things are running so a dogpile of events do not happen
-			UpdateCronMeetings(ctx, cron)
+			syncCron(pollCtx, cron, meetings) // this will block and we want that if things are running so a dogpile of events do not happen
+			updateCronMeetings(ctx, cron, providers)
 			return
 		}
 	}
 }
 
+// syncCron sends the cron only what changed between its schedule and the meetings
+func syncCron(ctx context.Context, cron *tasks.Cron, meetings calendar.MeetItems) {
+	add, remove := changes(cron.Tasks(), TaskWrapper(meetings))
+	cron.Change(ctx, add, remove)
+}
+
+// changes returns the tasks of want that are new or differ from the ones in have and the ids of the tasks of
+// have that want no longer has. A moved meeting has a new TaskID so it is removed and added.
+func changes(have, want tasks.SequentialTasks) (tasks.SequentialTasks, []string) {
+	had := map[string]tasks.Task{}
+	for _, t := range have {
+		had[tasks.TaskID(t)] = t
+	}
+	wanted := map[string]bool{}
+	for _, t := range want {
+		wanted[tasks.TaskID(t)] = true
+	}
+
+	// the meeting a task is of, a move keeps it
+	meeting := func(t tasks.Task) string {
+		if m, ok := t.(*MeetTaskImpl); ok && m.EventID != "" {
+			return m.Calendar + "/" + m.EventID
+		}
+		return t.Name()
+	}
+	removed := map[string]tasks.Task{}
+	var remove []string
+	for id, t := range had {
+		if !wanted[id] {
+			removed[meeting(t)] = t
+			remove = append(remove, id)
+		}
+	}
+	sort.Strings(remove)
+
+	var add tasks.SequentialTasks
+	for _, t := range want {
+		old, ok := had[tasks.TaskID(t)]
+		switch {
+		case !ok && removed[meeting(t)] != nil:
+			logrus.Infof("Meeting moved: %s from %s to %s", t.Name(), removed[meeting(t)].Start(), t.Start())
+			delete(removed, meeting(t))
+		case !ok:
+			logrus.Infof("Meeting added: %s starts: %s", t.Name(), t.Start())
+		case !cmp.Equal(old, t):
+			logrus.Infof("Meeting changed: %s", t.Name())
+		default:
+			continue
+		}
+		add = append(add, t)
+	}
+	for _, t := range removed {
+		logrus.Infof("Meeting cancelled: %s starts: %s", t.Name(), t.Start())
+	}
+
+	return add, remove
+}
+
 // TODO: think of how to do this more efficiently without copies just to know
 func PruneTasks(tsks tasks.SequentialTasks) tasks.SequentialTasks {
 	for i := len(tsks) - 1; i >= 0; i-- {
@@ -136,9 +416,9 @@ func PruneTasks(tsks tasks.SequentialTasks) tasks.SequentialTasks {
 }
 
 // common code to getTasks
-func GetTasks(c *utils.Config) (tasks.SequentialTasks, error) {
+func GetTasks(ctx context.Context, c *utils.Config) (tasks.SequentialTasks, error) {
 
-	meetings, err := FindMeetings(c)
+	meetings, err := FindMeetings(ctx, c)
 	if err != nil {
 		return nil, err
 	}
@@ -148,11 +428,52 @@ func GetTasks(c *utils.Config) (tasks.SequentialTasks, error) {
 }
 
 // findMeetings is invoked via a go-routine which periodically polls the calender to update the meetings for the day.
-func FindMeetings(c *utils.Config) (calendar.MeetItems, error) {
+func FindMeetings(ctx context.Context, c *utils.Config) (calendar.MeetItems, error) {
+
+	providers, err := calendar.Providers(c)
+	if err != nil {
+		return nil, err
+	}
+
+	from, to := c.Window(time.Now())
+	return findMeetings(ctx, providers, from, to)
+
+}
+
+// findMeetings merges the meetings of the providers between from and to, one that fails is left out as long
+// as another answers
+func findMeetings(ctx context.Context, providers []calendar.Provider, from, to time.Time) (calendar.MeetItems, error) {
+	start := time.Now()
+
+	var lists []calendar.MeetItems
+	var errs []error
+	for _, p := range providers {
+		meetings, err := p.Meetings(ctx, from, to)
+		if err != nil {
+			logrus.Errorf("Calendar provider %T failed: %v", p, err)
+			errs = append(errs, err)
+			continue
+		}
+		lists = append(lists, meetings)
+	}
 
-	cal := calendar.NewCalService(c)
-	return cal.GetUpcomingMeetings()
+	var err error
+	if len(errs) == len(providers) {
+		err = errors.Join(errs...)
+	}
+	daemonHealth.set(HealthCalendar, err)
+	metrics.CalendarPoll(start, err)
+	lastPoll.record(from, to, providers)
+	if err != nil {
+		return nil, err
+	}
 
+	meetings := calendar.Merge(lists...)
+	if len(meetings) == 0 {
+		// an empty calendar is not a broken one
+		return meetings, calendar.ErrNoUpcomingEvents
+	}
+	return meetings, nil
 }
 
 // convert the meeting items to meeting tasks
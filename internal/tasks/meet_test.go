@@ -0,0 +1,188 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/dathan/go-grpc-video-call-manager/internal/utils"
+	"github.com/dathan/go-grpc-video-call-manager/pkg/calendar"
+	"github.com/dathan/go-grpc-video-call-manager/pkg/tasks"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// fakeProvider returns the meetings or the error it was given
+type fakeProvider struct {
+	meetings  calendar.MeetItems
+	decisions []calendar.Decision
+	err       error
+	from, to  time.Time
+}
+
+func (f *fakeProvider) Decisions() []calendar.Decision {
+	return f.decisions
+}
+
+func (f *fakeProvider) Meetings(_ context.Context, from, to time.Time) (calendar.MeetItems, error) {
+	f.from, f.to = from, to
+	return f.meetings, f.err
+}
+
+func Test_findMeetings(t *testing.T) {
+	at := time.Now().Add(time.Hour)
+	standup := calendar.MeetItem{Uri: "https://meet.google.com/frt-ywwd-epk", Summary: "standup", StartTime: at}
+	planning := calendar.MeetItem{Uri: "https://meet.google.com/abc-defg-hij", Summary: "planning", StartTime: at.Add(-30 * time.Minute)}
+	broken := &fakeProvider{err: errors.New("oauth2: token expired")}
+	from := time.Now()
+	to := from.Add(24 * time.Hour)
+
+	tests := []struct {
+		name      string
+		providers []calendar.Provider
+		want      []string
+		wantErr   error
+	}{
+		{"merged", []calendar.Provider{&fakeProvider{meetings: calendar.MeetItems{standup}}, &fakeProvider{meetings: calendar.MeetItems{standup, planning}}}, []string{"planning", "standup"}, nil},
+		{"one broken", []calendar.Provider{broken, &fakeProvider{meetings: calendar.MeetItems{standup}}}, []string{"standup"}, nil},
+		{"all broken", []calendar.Provider{broken}, nil, broken.err},
+		{"empty", []calendar.Provider{&fakeProvider{}}, nil, calendar.ErrNoUpcomingEvents},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := findMeetings(context.Background(), tt.providers, from, to)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("findMeetings() error = %v, want %v", err, tt.wantErr)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("findMeetings() = %+v, want %v", got, tt.want)
+			}
+			for i, mi := range got {
+				if mi.Summary != tt.want[i] {
+					t.Errorf("findMeetings()[%d] = %s, want %s", i, mi.Summary, tt.want[i])
+				}
+			}
+		})
+	}
+
+	if !broken.from.Equal(from) || !broken.to.Equal(to) {
+		t.Errorf("providers were asked for %s to %s, want %s to %s", broken.from, broken.to, from, to)
+	}
+}
+
+func Test_setupProviders(t *testing.T) {
+	defer daemonHealth.set(HealthCalendar, nil)
+
+	config := &utils.Config{Providers: []utils.ProviderConfig{{Type: "ics"}}}
+	if _, err := setupProviders(config); err == nil {
+		t.Fatalf("setupProviders() of an ics feed without a url error = nil")
+	}
+	if daemonHealth.errors[HealthCalendar] == nil {
+		t.Errorf("the calendar is healthy with providers that cannot be set up")
+	}
+
+	// fixed in the config, the next poll sets them up
+	config.Providers[0].URL = "https://example.com/calendar.ics"
+	if providers, err := setupProviders(config); err != nil || len(providers) != 1 {
+		t.Errorf("setupProviders() = %v, %v want the ics provider", providers, err)
+	}
+}
+
+func TestMeetTaskImpl_execute_maxSnooze(t *testing.T) {
+	// snoozed as long as it can be and launched a timer tick late
+	late := tasks.MaxSnooze + tasks.TIMER_TICK*time.Second
+	m := &MeetTaskImpl{calendar.MeetItem{Uri: "https://meet.google.com/frt-ywwd-epk", Summary: "standup", StartTime: time.Now().Add(time.Duration(tasks.MAGIC_DELTA)*time.Second - late)}}
+
+	cron := tasks.NewCron(context.Background(), tasks.SequentialTasks{m}, &utils.Config{})
+	if err := cron.Snooze(tasks.TaskID(m), tasks.MaxSnooze+time.Second); !errors.Is(err, tasks.ErrSnoozeTooLong) {
+		t.Errorf("Cron.Snooze() past MaxSnooze error = %v, want %v", err, tasks.ErrSnoozeTooLong)
+	}
+	if err := cron.Snooze(tasks.TaskID(m), tasks.MaxSnooze); err != nil {
+		t.Fatalf("Cron.Snooze() of MaxSnooze error = %v", err)
+	}
+
+	// the backend is down, the task has to get as far as trying it
+	config := &utils.Config{Backend: "localhost:50051", TLS: &utils.TLSConfig{CAFile: filepath.Join(t.TempDir(), "ca.pem")}}
+	if err := m.execute(context.Background(), config); errors.Is(err, tasks.ErrSkipped) || status.Code(err) != codes.Unavailable {
+		t.Errorf("execute() of a task snoozed MaxSnooze error = %v, want it tried", err)
+	}
+}
+
+func Test_server_GetCalendarPoll(t *testing.T) {
+	from := time.Now()
+	to := from.Add(24 * time.Hour)
+	standup := calendar.MeetItem{Uri: "https://meet.google.com/frt-ywwd-epk", Summary: "standup", StartTime: from.Add(time.Hour)}
+	p := &fakeProvider{
+		meetings: calendar.MeetItems{standup},
+		decisions: []calendar.Decision{
+			{Calendar: "primary", EventID: "standup", Summary: "standup", Start: standup.StartTime, Kept: true, Reason: "invited"},
+			{Calendar: "primary", EventID: "lunch", Summary: "lunch", Start: from.Add(2 * time.Hour), Reason: "no google meet link"},
+		},
+	}
+	if _, err := findMeetings(context.Background(), []calendar.Provider{p}, from, to); err != nil {
+		t.Fatalf("findMeetings() error = %v", err)
+	}
+
+	s := newServer(tasks.NewCron(context.Background(), tasks.SequentialTasks{}, &utils.Config{}), PolicyReject)
+	got, err := s.GetCalendarPoll(context.Background(), &emptypb.Empty{})
+	if err != nil {
+		t.Fatalf("GetCalendarPoll() error = %v", err)
+	}
+	if !got.From.AsTime().Equal(from) || !got.To.AsTime().Equal(to) || len(got.Events) != 2 {
+		t.Fatalf("GetCalendarPoll() = %v, want both events of the window", got)
+	}
+	if e := got.Events[1]; e.EventId != "lunch" || e.Kept || e.Reason != "no google meet link" {
+		t.Errorf("GetCalendarPoll() lunch = %v, want it dropped for the missing link", e)
+	}
+
+	if _, err := (server{}).GetCalendarPoll(context.Background(), &emptypb.Empty{}); status.Code(err) != codes.Unavailable {
+		t.Errorf("GetCalendarPoll() without a scheduler error = %v, want Unavailable", err)
+	}
+}
+
+func Test_changes(t *testing.T) {
+	at := time.Now().Add(time.Hour)
+	standup := calendar.MeetItem{Uri: "https://meet.google.com/frt-ywwd-epk", Summary: "standup", StartTime: at, Calendar: "primary", EventID: "s1"}
+	planning := calendar.MeetItem{Uri: "https://meet.google.com/abc-defg-hij", Summary: "planning", StartTime: at.Add(time.Hour), Calendar: "primary", EventID: "p1"}
+	moved := planning
+	moved.StartTime = at.Add(2 * time.Hour)
+	renamed := standup
+	renamed.Rooms = []string{"HQ-2 Boardroom (12)"}
+	id := func(mi calendar.MeetItem) string { return tasks.TaskID(&MeetTaskImpl{mi}) }
+
+	tests := []struct {
+		name       string
+		have, want calendar.MeetItems
+		wantAdd    []string
+		wantRemove []string
+	}{
+		{"unchanged", calendar.MeetItems{standup, planning}, calendar.MeetItems{standup, planning}, nil, nil},
+		{"added", calendar.MeetItems{standup}, calendar.MeetItems{standup, planning}, []string{id(planning)}, nil},
+		{"cancelled", calendar.MeetItems{standup, planning}, calendar.MeetItems{planning}, nil, []string{id(standup)}},
+		{"moved", calendar.MeetItems{standup, planning}, calendar.MeetItems{standup, moved}, []string{id(moved)}, []string{id(planning)}},
+		{"changed", calendar.MeetItems{standup}, calendar.MeetItems{renamed}, []string{id(renamed)}, nil},
+		{"all cancelled", calendar.MeetItems{standup, planning}, nil, nil, []string{id(planning), id(standup)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			add, remove := changes(TaskWrapper(tt.have), TaskWrapper(tt.want))
+			var gotAdd []string
+			for _, a := range add {
+				gotAdd = append(gotAdd, tasks.TaskID(a))
+			}
+			if !cmp.Equal(gotAdd, tt.wantAdd) {
+				t.Errorf("changes() add = %v, want %v", gotAdd, tt.wantAdd)
+			}
+			want := append([]string(nil), tt.wantRemove...)
+			sort.Strings(want)
+			if !cmp.Equal(remove, want) {
+				t.Errorf("changes() remove = %v, want %v", remove, tt.wantRemove)
+			}
+		})
+	}
+}
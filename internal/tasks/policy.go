@@ -0,0 +1,139 @@
+package tasks
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/dathan/go-grpc-video-call-manager/pkg/manager"
+	"github.com/sirupsen/logrus"
+)
+
+// ErrSessionBusy is returned when another meeting has the browser and the policy rejects the join
+var ErrSessionBusy = errors.New("another meeting has the browser")
+
+// Policy is what the server does with a join while another meeting has the browser.
+// Chrome cannot run twice on the Session profile so only one meeting has the browser at a time.
+type Policy int
+
+const (
+	PolicyReject  Policy = iota // fail the join with SESSION_BUSY
+	PolicyQueue                 // join once the meetings ahead of it are over
+	PolicyReplace               // leave the current meeting and join this one
+)
+
+var policyNames = map[string]Policy{
+	"":        PolicyReject,
+	"reject":  PolicyReject,
+	"queue":   PolicyQueue,
+	"replace": PolicyReplace,
+}
+
+// ParsePolicy reads the concurrency_policy setting, reject when it is empty
+func ParsePolicy(s string) (Policy, error) {
+	p, ok := policyNames[s]
+	if !ok {
+		return PolicyReject, fmt.Errorf("unknown concurrency policy %q, want reject, queue or replace", s)
+	}
+	return p, nil
+}
+
+// browser hands the one browser profile to one meeting at a time
+type browser struct {
+	mu      sync.Mutex
+	turn    *sync.Cond
+	policy  Policy
+	current *meeting   // has the browser
+	waiting []*meeting // in the order they get the browser
+}
+
+func newBrowser(policy Policy) *browser {
+	b := &browser{policy: policy}
+	b.turn = sync.NewCond(&b.mu)
+	return b
+}
+
+// admit decides what happens to m, a STARTED meeting has the browser right away
+func (b *browser) admit(m *meeting) (manager.Decision, error) {
+	b.mu.Lock()
+
+	if b.current == nil && len(b.waiting) == 0 {
+		b.current = m
+		b.mu.Unlock()
+		return manager.Decision_STARTED, nil
+	}
+
+	switch b.policy {
+	case PolicyQueue:
+		b.waiting = append(b.waiting, m)
+		b.mu.Unlock()
+		return manager.Decision_QUEUED, nil
+
+	case PolicyReplace:
+		// everyone waiting is passed over too, the newest join is the one that is wanted
+		leaving := b.waiting
+		b.waiting = []*meeting{m}
+		if b.current != nil {
+			logrus.Infof("Replacing meeting %s => %s with %s", b.current.id, b.current.uri, m.id)
+			leaving = append(leaving, b.current)
+		}
+		b.mu.Unlock()
+
+		// a browser can take a while to shut down, the others do not wait on it
+		for _, w := range leaving {
+			w.leave()
+		}
+		b.wake()
+		return manager.Decision_REPLACED, nil
+	}
+
+	b.mu.Unlock()
+	return manager.Decision_REJECTED, ErrSessionBusy
+}
+
+// wait blocks until m has the browser, false when m was left before it got it
+func (b *browser) wait(m *meeting) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for b.current != m {
+		if m.isLeaving() {
+			b.drop(m)
+			return false
+		}
+		b.turn.Wait()
+	}
+	return true
+}
+
+// release gives the browser to the next meeting once m is over
+func (b *browser) release(m *meeting) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.drop(m)
+	if b.current == m {
+		b.current = nil
+		if len(b.waiting) > 0 {
+			b.current = b.waiting[0]
+			b.waiting = b.waiting[1:]
+		}
+	}
+	b.turn.Broadcast()
+}
+
+// wake makes the waiting meetings look at their state, after one of them was left
+func (b *browser) wake() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.turn.Broadcast()
+}
+
+// drop takes m out of the line, callers hold mu
+func (b *browser) drop(m *meeting) {
+	for i, w := range b.waiting {
+		if w == m {
+			b.waiting = append(b.waiting[:i], b.waiting[i+1:]...)
+			return
+		}
+	}
+}
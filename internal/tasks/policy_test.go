@@ -0,0 +1,100 @@
+package tasks
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dathan/go-grpc-video-call-manager/pkg/manager"
+	"github.com/dathan/go-grpc-video-call-manager/pkg/session"
+)
+
+func TestParsePolicy(t *testing.T) {
+	for in, want := range map[string]Policy{"": PolicyReject, "reject": PolicyReject, "queue": PolicyQueue, "replace": PolicyReplace} {
+		if got, err := ParsePolicy(in); err != nil || got != want {
+			t.Errorf("ParsePolicy(%q) = %v, %v want %v", in, got, err, want)
+		}
+	}
+	if _, err := ParsePolicy("share"); err == nil {
+		t.Errorf("ParsePolicy() of an unknown policy should fail")
+	}
+}
+
+// turn reports when m gets the browser
+func turn(b *browser, m *meeting) chan bool {
+	ch := make(chan bool, 1)
+	go func() { ch <- b.wait(m) }()
+	return ch
+}
+
+func Test_browser(t *testing.T) {
+	r := newRegistry()
+	newMeeting := func() *meeting { return r.add("https://meet.google.com/frt-ywwd-epk", &session.Session{}) }
+
+	t.Run("reject", func(t *testing.T) {
+		b := newBrowser(PolicyReject)
+		first, second := newMeeting(), newMeeting()
+		if d, err := b.admit(first); err != nil || d != manager.Decision_STARTED {
+			t.Fatalf("admit() of the first meeting = %s, %v", d, err)
+		}
+		if d, err := b.admit(second); !errors.Is(err, ErrSessionBusy) || d != manager.Decision_REJECTED {
+			t.Errorf("admit() of the second meeting = %s, %v want %s, %v", d, err, manager.Decision_REJECTED, ErrSessionBusy)
+		}
+		b.release(first)
+		if d, err := b.admit(second); err != nil || d != manager.Decision_STARTED {
+			t.Errorf("admit() once the browser is free = %s, %v", d, err)
+		}
+	})
+
+	t.Run("queue", func(t *testing.T) {
+		b := newBrowser(PolicyQueue)
+		first, second, third := newMeeting(), newMeeting(), newMeeting()
+		b.admit(first)
+		for _, m := range []*meeting{second, third} {
+			if d, err := b.admit(m); err != nil || d != manager.Decision_QUEUED {
+				t.Fatalf("admit() while busy = %s, %v want %s", d, err, manager.Decision_QUEUED)
+			}
+		}
+
+		secondTurn, thirdTurn := turn(b, second), turn(b, third)
+		select {
+		case <-secondTurn:
+			t.Fatalf("the queued meeting got the browser while it was busy")
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		// the third meeting is left while it waits, it gives up its place
+		third.leave()
+		b.wake()
+		if got := <-thirdTurn; got {
+			t.Errorf("a meeting left while queued got the browser")
+		}
+
+		b.release(first)
+		if got := <-secondTurn; !got {
+			t.Errorf("the queued meeting did not get the browser")
+		}
+		if b.current != second || len(b.waiting) != 0 {
+			t.Errorf("browser is held by %v with %d waiting, want the second meeting alone", b.current, len(b.waiting))
+		}
+	})
+
+	t.Run("replace", func(t *testing.T) {
+		b := newBrowser(PolicyReplace)
+		first, second := newMeeting(), newMeeting()
+		b.admit(first)
+		if d, err := b.admit(second); err != nil || d != manager.Decision_REPLACED {
+			t.Fatalf("admit() while busy = %s, %v want %s", d, err, manager.Decision_REPLACED)
+		}
+		if !first.isLeaving() {
+			t.Errorf("the replaced meeting was not asked to leave")
+		}
+
+		// the browser is only handed over once the replaced meeting is gone
+		secondTurn := turn(b, second)
+		b.release(first)
+		if got := <-secondTurn; !got {
+			t.Errorf("the replacing meeting did not get the browser")
+		}
+	})
+}
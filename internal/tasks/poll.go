@@ -0,0 +1,81 @@
+package tasks
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/dathan/go-grpc-video-call-manager/pkg/calendar"
+	"github.com/dathan/go-grpc-video-call-manager/pkg/manager"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// pollState keeps what the last calendar poll considered
+type pollState struct {
+	mu        sync.Mutex
+	time      time.Time
+	from, to  time.Time
+	decisions []calendar.Decision
+}
+
+// the calendar poll records, the scheduler api reads
+var lastPoll = &pollState{}
+
+// record logs why each event was kept or dropped and keeps the decisions for GetCalendarPoll
+func (p *pollState) record(from, to time.Time, providers []calendar.Provider) {
+	var decisions []calendar.Decision
+	for _, pr := range providers {
+		if e, ok := pr.(calendar.Explainer); ok {
+			decisions = append(decisions, e.Decisions()...)
+		}
+	}
+
+	kept := 0
+	for _, d := range decisions {
+		verb := "Dropping"
+		if d.Kept {
+			verb = "Keeping"
+			kept++
+		}
+		logrus.Debugf("%s %q at %s on %s: %s", verb, d.Summary, d.Start.Format(time.RFC3339), d.Calendar, d.Reason)
+	}
+	logrus.Debugf("Calendar poll from %s to %s kept %d of %d events", from.Format(time.RFC3339), to.Format(time.RFC3339), kept, len(decisions))
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.time, p.from, p.to, p.decisions = time.Now(), from, to, decisions
+}
+
+// GetCalendarPoll returns the events the last calendar poll read and why each was kept or dropped
+func (s server) GetCalendarPoll(c context.Context, _ *emptypb.Empty) (*manager.CalendarPoll, error) {
+	if s.cron == nil {
+		return nil, status.Error(codes.Unavailable, "no scheduler is running")
+	}
+
+	lastPoll.mu.Lock()
+	defer lastPoll.mu.Unlock()
+	if lastPoll.time.IsZero() {
+		return nil, status.Error(codes.NotFound, "the calendar was not polled yet")
+	}
+
+	ret := &manager.CalendarPoll{
+		Time: timestamppb.New(lastPoll.time),
+		From: timestamppb.New(lastPoll.from),
+		To:   timestamppb.New(lastPoll.to),
+	}
+	for _, d := range lastPoll.decisions {
+		ret.Events = append(ret.Events, &manager.CalendarEvent{
+			Calendar: d.Calendar,
+			EventId:  d.EventID,
+			Summary:  d.Summary,
+			Start:    timestamppb.New(d.Start),
+			Kept:     d.Kept,
+			Reason:   d.Reason,
+		})
+	}
+	return ret, nil
+}
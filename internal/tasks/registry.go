@@ -0,0 +1,161 @@
+package tasks
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/dathan/go-grpc-video-call-manager/pkg/manager"
+	"github.com/dathan/go-grpc-video-call-manager/pkg/session"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// meeting is a live browser session the server is driving
+type meeting struct {
+	id      string
+	uri     string
+	started time.Time
+	session *session.Session
+
+	mu      sync.Mutex
+	state   manager.MeetEventType
+	leaving bool                             // LeaveMeeting was called, errors from here on are expected
+	events  []*manager.MeetEvent             // history so late subscribers see every step
+	final   *manager.MeetEvent               // the ERROR or LEFT that ended the meeting, a slow reader may have missed it
+	subs    map[chan *manager.MeetEvent]bool // listeners of the event stream
+	done    chan struct{}                    // closed when the meeting is over
+}
+
+// publish records the event and hands it to every subscriber
+func (m *meeting) publish(ev *manager.MeetEvent) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	ev.SessionId = m.id
+	m.state = ev.Type
+	m.events = append(m.events, ev)
+	if isFinal(ev) {
+		m.final = ev
+	}
+	for ch := range m.subs {
+		select {
+		case ch <- ev:
+		default: // a slow reader should not stall the browser, it gets the final event once the meeting is over
+		}
+	}
+}
+
+// finalEvent returns the event that ended the meeting, nil while it is live
+func (m *meeting) finalEvent() *manager.MeetEvent {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.final
+}
+
+// isFinal is true for the events that end a meeting
+func isFinal(ev *manager.MeetEvent) bool {
+	return ev.Type == manager.MeetEventType_ERROR || ev.Type == manager.MeetEventType_LEFT
+}
+
+// subscribe returns the events seen so far and a channel for the ones to come
+func (m *meeting) subscribe() ([]*manager.MeetEvent, chan *manager.MeetEvent) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	ch := make(chan *manager.MeetEvent, 16)
+	m.subs[ch] = true
+	return append([]*manager.MeetEvent{}, m.events...), ch
+}
+
+func (m *meeting) unsubscribe(ch chan *manager.MeetEvent) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.subs, ch)
+}
+
+// leave asks the browser to exit, the join routine reports LEFT once it is gone
+func (m *meeting) leave() {
+	m.mu.Lock()
+	m.leaving = true
+	m.mu.Unlock()
+	m.session.Shutdown()
+}
+
+func (m *meeting) isLeaving() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.leaving
+}
+
+func (m *meeting) toProto() *manager.ActiveMeeting {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return &manager.ActiveMeeting{
+		SessionId: m.id,
+		Uri:       m.uri,
+		State:     m.state,
+		Started:   timestamppb.New(m.started),
+	}
+}
+
+// registry keeps track of the live meetings by session id
+type registry struct {
+	mu       sync.Mutex
+	meetings map[string]*meeting
+}
+
+func newRegistry() *registry {
+	return &registry{meetings: map[string]*meeting{}}
+}
+
+// add registers a new meeting for the session
+func (r *registry) add(uri string, s *session.Session) *meeting {
+	m := &meeting{
+		id:      newSessionID(),
+		uri:     uri,
+		started: time.Now(),
+		session: s,
+		subs:    map[chan *manager.MeetEvent]bool{},
+		done:    make(chan struct{}),
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.meetings[m.id] = m
+	return m
+}
+
+// remove drops the meeting and wakes up anyone waiting on it
+func (r *registry) remove(m *meeting) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.meetings, m.id)
+	close(m.done)
+}
+
+func (r *registry) get(id string) (*meeting, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	m, ok := r.meetings[id]
+	return m, ok
+}
+
+// list returns the live meetings oldest first
+func (r *registry) list() []*meeting {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ms := make([]*meeting, 0, len(r.meetings))
+	for _, m := range r.meetings {
+		ms = append(ms, m)
+	}
+	sort.Slice(ms, func(i, j int) bool { return ms[i].started.Before(ms[j].started) })
+	return ms
+}
+
+func newSessionID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return time.Now().Format("20060102150405.000000000")
+	}
+	return hex.EncodeToString(b)
+}
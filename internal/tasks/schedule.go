@@ -0,0 +1,221 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"time"
+
+	"github.com/dathan/go-grpc-video-call-manager/pkg/calendar"
+	"github.com/dathan/go-grpc-video-call-manager/pkg/history"
+	"github.com/dathan/go-grpc-video-call-manager/pkg/manager"
+	"github.com/dathan/go-grpc-video-call-manager/pkg/tasks"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ListSchedule returns every task the cron knows about in the order it runs them
+func (s server) ListSchedule(c context.Context, _ *emptypb.Empty) (*manager.Schedule, error) {
+	if s.cron == nil {
+		return nil, status.Error(codes.Unavailable, "no scheduler is running")
+	}
+
+	ret := &manager.Schedule{}
+	for _, st := range s.cron.Schedule() {
+		ret.Tasks = append(ret.Tasks, toScheduledTask(st))
+	}
+	return ret, nil
+}
+
+// GetNextTask returns the first task that is waiting to run
+func (s server) GetNextTask(c context.Context, _ *emptypb.Empty) (*manager.ScheduledTask, error) {
+	if s.cron == nil {
+		return nil, status.Error(codes.Unavailable, "no scheduler is running")
+	}
+
+	st, ok := s.cron.Next()
+	if !ok {
+		return nil, status.Error(codes.NotFound, "no pending tasks")
+	}
+	return toScheduledTask(st), nil
+}
+
+// SkipTask makes the cron pass over the task
+func (s server) SkipTask(c context.Context, req *manager.TaskRequest) (*manager.ScheduledTask, error) {
+	return s.override(req.Id, func() error { return s.cron.Skip(req.Id) })
+}
+
+// SnoozeTask delays the launch of the task
+func (s server) SnoozeTask(c context.Context, req *manager.SnoozeRequest) (*manager.ScheduledTask, error) {
+	d := req.Duration.AsDuration()
+	if d <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "snooze duration must be positive")
+	}
+	return s.override(req.Id, func() error { return s.cron.Snooze(req.Id, d) })
+}
+
+// ForceJoinTask runs the task now
+func (s server) ForceJoinTask(c context.Context, req *manager.TaskRequest) (*manager.ScheduledTask, error) {
+	return s.override(req.Id, func() error { return s.cron.ForceJoin(req.Id) })
+}
+
+// ClearTaskOverride puts the task back on its calendar schedule
+func (s server) ClearTaskOverride(c context.Context, req *manager.TaskRequest) (*manager.ScheduledTask, error) {
+	return s.override(req.Id, func() error { return s.cron.ClearOverride(req.Id) })
+}
+
+// override applies the change to the cron and returns the task as it is now scheduled
+func (s server) override(id string, apply func() error) (*manager.ScheduledTask, error) {
+	if s.cron == nil {
+		return nil, status.Error(codes.Unavailable, "no scheduler is running")
+	}
+
+	if err := apply(); err != nil {
+		if errors.Is(err, tasks.ErrUnknownTask) {
+			return nil, status.Errorf(codes.NotFound, "no task with id %s", id)
+		}
+		if errors.Is(err, tasks.ErrSnoozeTooLong) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return s.scheduled(id)
+}
+
+// QueueMeeting adds a meeting that is not on the calendar to the cron
+func (s server) QueueMeeting(c context.Context, req *manager.QueueRequest) (*manager.ScheduledTask, error) {
+	if s.cron == nil {
+		return nil, status.Error(codes.Unavailable, "no scheduler is running")
+	}
+
+	if u, err := url.Parse(req.Uri); err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "%q is not a meeting link", req.Uri)
+	}
+	if req.Start == nil {
+		return nil, status.Error(codes.InvalidArgument, "start is required")
+	}
+
+	item := calendar.MeetItem{
+		Uri:       req.Uri,
+		Summary:   req.Name,
+		StartTime: req.Start.AsTime().Local(),
+	}
+	if item.Summary == "" {
+		item.Summary = "Ad-hoc meeting"
+	}
+	if req.End != nil {
+		item.EndTime = req.End.AsTime().Local()
+		if !item.EndTime.After(item.StartTime) {
+			return nil, status.Error(codes.InvalidArgument, "end must be after start")
+		}
+	}
+	if time.Since(item.StartTime).Seconds() > tasks.MAGIC_DELTA {
+		// the join skips a meeting that started this long ago
+		return nil, status.Errorf(codes.InvalidArgument, "the meeting started at %s, too long ago to join", item.StartTime.Format(time.Kitchen))
+	}
+
+	id, err := s.cron.Queue(&MeetTaskImpl{item})
+	switch {
+	case errors.Is(err, tasks.ErrDuplicateTask):
+		return nil, status.Errorf(codes.AlreadyExists, "%s is already queued", item.Summary)
+	case errors.Is(err, tasks.ErrTaskOver):
+		return nil, status.Error(codes.InvalidArgument, "the meeting is already over")
+	case err != nil:
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return s.scheduled(id)
+}
+
+// QueryHistory returns the recorded decisions and joins in the range
+func (s server) QueryHistory(c context.Context, req *manager.HistoryRequest) (*manager.History, error) {
+	if s.cron == nil {
+		return nil, status.Error(codes.Unavailable, "no scheduler is running")
+	}
+
+	var from, to time.Time
+	if req.From != nil {
+		from = req.From.AsTime()
+	}
+	if req.To != nil {
+		to = req.To.AsTime()
+	}
+	if !from.IsZero() && !to.IsZero() && !to.After(from) {
+		return nil, status.Error(codes.InvalidArgument, "to must be after from")
+	}
+
+	records, err := s.cron.History.Query(from, to)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	ret := &manager.History{}
+	for _, r := range records {
+		ret.Records = append(ret.Records, toHistoryRecord(r))
+	}
+	return ret, nil
+}
+
+// toHistoryRecord converts a history record to the wire format
+func toHistoryRecord(r history.Record) *manager.HistoryRecord {
+	ret := &manager.HistoryRecord{
+		Time:      timestamppb.New(r.Time),
+		Event:     r.Event,
+		TaskId:    r.TaskID,
+		Name:      r.Name,
+		Uri:       r.URI,
+		Scheduled: timestamppb.New(r.Scheduled),
+		Attempt:   int32(r.Attempt),
+		Outcome:   r.Outcome,
+		Error:     r.Error,
+	}
+	if r.Joined != nil {
+		ret.Joined = timestamppb.New(*r.Joined)
+	}
+	return ret
+}
+
+// scheduled returns the task with the id as it is now scheduled
+func (s server) scheduled(id string) (*manager.ScheduledTask, error) {
+	for _, st := range s.cron.Schedule() {
+		if st.ID == id {
+			return toScheduledTask(st), nil
+		}
+	}
+	return nil, status.Errorf(codes.NotFound, "no task with id %s", id)
+}
+
+// toScheduledTask converts the cron snapshot to the wire format, the task state and override are numbered like the proto enums
+func toScheduledTask(st tasks.ScheduledTask) *manager.ScheduledTask {
+	ret := &manager.ScheduledTask{
+		Id:       st.ID,
+		Name:     st.Task.Name(),
+		Start:    timestamppb.New(st.Task.Start()),
+		FireTime: timestamppb.New(st.FireTime),
+		State:    manager.TaskState(st.State),
+		Override: manager.TaskOverride(st.Override),
+		Attempts: int32(st.Attempts),
+		AdHoc:    st.AdHoc,
+	}
+
+	if !st.Task.End().IsZero() {
+		ret.End = timestamppb.New(st.Task.End())
+	}
+
+	if st.Snooze > 0 {
+		ret.Snooze = durationpb.New(st.Snooze)
+	}
+
+	if mt, ok := st.Task.(*MeetTaskImpl); ok {
+		ret.Uri = mt.Uri
+	}
+
+	if st.Err != nil {
+		ret.Error = st.Err.Error()
+	}
+	return ret
+}
@@ -0,0 +1,44 @@
+package tasks
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dathan/go-grpc-video-call-manager/internal/utils"
+	"github.com/dathan/go-grpc-video-call-manager/pkg/manager"
+	"github.com/dathan/go-grpc-video-call-manager/pkg/tasks"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func Test_server_QueueMeeting(t *testing.T) {
+	uri := "https://meet.google.com/abc-defg-hij"
+	now := time.Now()
+
+	tests := []struct {
+		name string
+		req  *manager.QueueRequest
+		want codes.Code
+	}{
+		{"soon", &manager.QueueRequest{Uri: uri, Start: timestamppb.New(now.Add(time.Hour))}, codes.OK},
+		{"just started", &manager.QueueRequest{Uri: uri, Start: timestamppb.New(now.Add(-5 * time.Minute))}, codes.OK},
+		{"started too long ago", &manager.QueueRequest{Uri: uri, Start: timestamppb.New(now.Add(-30 * time.Minute)), End: timestamppb.New(now.Add(time.Hour))}, codes.InvalidArgument},
+		{"no start", &manager.QueueRequest{Uri: uri}, codes.InvalidArgument},
+		{"not a link", &manager.QueueRequest{Uri: "abc-defg-hij", Start: timestamppb.New(now)}, codes.InvalidArgument},
+		{"end before start", &manager.QueueRequest{Uri: uri, Start: timestamppb.New(now), End: timestamppb.New(now.Add(-time.Minute))}, codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newServer(tasks.NewCron(context.Background(), nil, &utils.Config{}), PolicyReject)
+			got, err := s.QueueMeeting(context.Background(), tt.req)
+			if status.Code(err) != tt.want {
+				t.Fatalf("server.QueueMeeting() error = %v, want %s", err, tt.want)
+			}
+			if err == nil && got.State != manager.TaskState_PENDING {
+				t.Errorf("server.QueueMeeting() state = %s, want PENDING", got.State)
+			}
+		})
+	}
+}
@@ -4,40 +4,244 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"time"
 
 	"github.com/chromedp/chromedp"
+	"github.com/dathan/go-grpc-video-call-manager/internal/metrics"
 	"github.com/dathan/go-grpc-video-call-manager/internal/utils"
 	"github.com/dathan/go-grpc-video-call-manager/pkg/manager"
 	"github.com/dathan/go-grpc-video-call-manager/pkg/session"
+	"github.com/dathan/go-grpc-video-call-manager/pkg/tasks"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type server struct {
 	manager.UnimplementedOpenMeetUrlServer
+	manager.UnimplementedSchedulerServer
+	manager.UnimplementedDispatcherServer
+	meetings *registry
+	browser  *browser
+	cron     *tasks.Cron
 }
 
-// OpenMeetUrl for the local server open the meet url
+func newServer(cron *tasks.Cron, policy Policy) server {
+	return server{meetings: newRegistry(), browser: newBrowser(policy), cron: cron}
+}
+
+// OpenMeetUrl for the local server open the meet url, the meeting is joined in the background
 func (s server) OpenMeetUrl(c context.Context, man *manager.Meet) (*manager.Status, error) {
+	m, decision, err := s.start(c, man)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return &manager.Status{
+		Ok:        true,
+		SessionId: m.id,
+		Decision:  decision,
+	}, nil
+}
+
+// OpenMeetUrlEvents opens the meet url and streams every lifecycle step back to the caller.
+// The stream ends when the meeting is over, hanging up early leaves the meeting running.
+func (s server) OpenMeetUrlEvents(man *manager.Meet, stream manager.OpenMeetUrl_OpenMeetUrlEventsServer) error {
+	m, _, err := s.start(stream.Context(), man)
+	if err != nil {
+		return statusError(err)
+	}
+
+	return streamEvents(stream, m)
+}
+
+// streamEvents sends the events of the meeting until it is over. The final event is always sent, even to
+// a reader too slow to get it when it was published, so the stream ends with the typed status of a failure.
+func streamEvents(stream manager.OpenMeetUrl_OpenMeetUrlEventsServer, m *meeting) error {
+	seen, ch := m.subscribe()
+	defer m.unsubscribe(ch)
+
+	sentFinal := false
+	send := func(ev *manager.MeetEvent) error {
+		sentFinal = sentFinal || isFinal(ev)
+		return sendEvent(stream, ev)
+	}
+
+	for _, ev := range seen {
+		if err := send(ev); err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case ev := <-ch:
+			if err := send(ev); err != nil {
+				return err
+			}
+		case <-m.done:
+			// drain what was published before the meeting was removed
+			for {
+				select {
+				case ev := <-ch:
+					if err := send(ev); err != nil {
+						return err
+					}
+				default:
+					if ev := m.finalEvent(); ev != nil && !sentFinal {
+						return sendEvent(stream, ev)
+					}
+					return nil
+				}
+			}
+		case <-stream.Context().Done():
+			return nil
+		}
+	}
+}
+
+// sendEvent sends the event, an ERROR event ends the stream with the typed status of the failure
+func sendEvent(stream manager.OpenMeetUrl_OpenMeetUrlEventsServer, ev *manager.MeetEvent) error {
+	if err := stream.Send(ev); err != nil {
+		return err
+	}
+
+	if ev.Type == manager.MeetEventType_ERROR && ev.Error != nil {
+		return detailError(ev.Error)
+	}
+	return nil
+}
+
+// LeaveMeeting closes the browser of a live meeting
+func (s server) LeaveMeeting(c context.Context, req *manager.SessionRequest) (*manager.Status, error) {
+	m, ok := s.meetings.get(req.SessionId)
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "no active meeting for session %s", req.SessionId)
+	}
+
+	logrus.Infof("Leaving meeting %s => %s", m.id, m.uri)
+	m.leave()
+	s.browser.wake() // a queued meeting gives up its place
 
+	return &manager.Status{
+		Ok:        true,
+		SessionId: m.id,
+	}, nil
+}
+
+// ListActiveMeetings returns the meetings the server has a browser open for
+func (s server) ListActiveMeetings(c context.Context, _ *emptypb.Empty) (*manager.ActiveMeetings, error) {
+	ret := &manager.ActiveMeetings{}
+	for _, m := range s.meetings.list() {
+		ret.Meetings = append(ret.Meetings, m.toProto())
+	}
+	return ret, nil
+}
+
+// start registers a session for the meeting and joins it in a go routine once the concurrency policy lets it have the browser
+func (s server) start(c context.Context, man *manager.Meet) (*meeting, manager.Decision, error) {
+	metrics.JoinAttempt()
 	meet, err := session.NewSession()
 	if err != nil {
-		return &manager.Status{
-			Ok:       false,
-			ErrorMsg: err.Error(),
-		}, err
+		err = fmt.Errorf("%w: %w", session.ErrBrowserLaunch, err)
+		daemonHealth.set(HealthBrowser, err)
+		metrics.JoinOutcome(errorDetail(err).Code.String())
+		return nil, manager.Decision_STARTED, err
 	}
 
-	ctx, cancel := meet.NewContext()
+	meet.SetOptions(sessionOptions(man.Options))
+	m := s.meetings.add(man.Uri, meet)
+	joined := false // set from the join routine, the session emits its events on it
+	meet.OnEvent(func(ev session.Event) {
+		switch ev.Type {
+		case session.EventJoined:
+			joined = true
+			metrics.JoinOutcome(metrics.OutcomeJoined)
+		case session.EventPrejoin:
+			joined = true
+			metrics.JoinOutcome(metrics.OutcomePrejoin)
+		}
+		m.publish(toMeetEvent(man, ev))
+	})
+
+	decision, err := s.browser.admit(m)
+	if err != nil {
+		logrus.Warnf("Rejecting meeting %s => %s: %v", m.id, man.Uri, err)
+		metrics.JoinOutcome(metrics.OutcomeBusy)
+		s.meetings.remove(m)
+		return nil, decision, err
+	}
+
+	// the join outlives the call, it keeps the trace but not the deadline
+	ctx, span := tracer.Start(context.WithoutCancel(c), "session.join", trace.WithAttributes(
+		attribute.String("session.id", m.id),
+		attribute.String("meet.uri", man.Uri),
+		attribute.String("decision", decision.String()),
+	))
+
+	logrus.Infof("Starting meeting %s => %s (%s)", m.id, man.Uri, decision)
+	go func() {
+		defer span.End()
+		defer s.meetings.remove(m)
+		defer s.browser.release(m)
+
+		if decision != manager.Decision_STARTED {
+			m.publish(&manager.MeetEvent{Type: manager.MeetEventType_WAITING_FOR_BROWSER, Uri: man.Uri, Message: decision.String(), Time: timestamppb.Now()})
+			if !s.browser.wait(m) {
+				metrics.JoinOutcome(metrics.OutcomeLeft)
+				m.publish(toMeetEvent(man, session.Event{Type: session.EventLeft, Time: time.Now()}))
+				return
+			}
+		}
+
+		if err := s.join(ctx, meet, man); err != nil {
+			span.RecordError(err)
+			span.SetStatus(otelcodes.Error, err.Error())
+			if m.isLeaving() {
+				if !joined {
+					metrics.JoinOutcome(metrics.OutcomeLeft)
+				}
+				m.publish(toMeetEvent(man, session.Event{Type: session.EventLeft, Time: time.Now()}))
+				return
+			}
+			logrus.Errorf("Meeting %s failed: %v", m.id, err)
+			ev := toMeetEvent(man, session.Event{Type: session.EventError, Message: err.Error(), Time: time.Now()})
+			ev.Error = errorDetail(err)
+			if !joined {
+				metrics.JoinOutcome(ev.Error.Code.String())
+			}
+			m.publish(ev)
+		}
+	}()
+
+	return m, decision, nil
+}
+
+// join runs the steps to open the meeting and blocks until the browser exits
+func (s server) join(parent context.Context, meet *session.Session, man *manager.Meet) error {
+
+	ctx, cancel := meet.NewContext(parent)
 	defer cancel()
 
+	// a browser that cannot start takes the whole subsystem down until one does
+	err := meet.Launch(ctx)
+	daemonHealth.set(HealthBrowser, err)
+	if err != nil {
+		return err
+	}
+
 	err = meet.Login(ctx)
 
 	if err != nil {
-		return &manager.Status{
-			Ok:       false,
-			ErrorMsg: err.Error(),
-		}, err
+		return err
 	}
 
 	ctx1, cancel1 := chromedp.NewContext(ctx)
@@ -46,53 +250,90 @@ func (s server) OpenMeetUrl(c context.Context, man *manager.Meet) (*manager.Stat
 	err = chromedp.Run(ctx1, chromedp.Navigate("https://calendar.google.com/calendar/u/0/r?pli=1"))
 	//err = meet.Open(ctx1, "https://calendar.google.com/calendar/u/0/r?pli=1")
 	if err != nil {
-		return &manager.Status{
-			Ok:       false,
-			ErrorMsg: err.Error(),
-		}, err
+		return fmt.Errorf("%w: %w", session.ErrNavigation, err)
 	}
 
 	err = meet.Open(ctx, man.Uri)
 
 	if err != nil {
-		return &manager.Status{
-			Ok:       false,
-			ErrorMsg: err.Error(),
-		}, err
+		return err
 	}
 
 	err = meet.ApplySettings(ctx)
 	if err != nil {
-		return &manager.Status{
-			Ok:       false,
-			ErrorMsg: err.Error(),
-		}, err
+		return err
 	}
 
 	//Waiting means you need to wait for the browser process to exit
 	//TODO - wait for the tab to exit so you can avoid the browser context wait lock
 	meet.Wait(ctx)
 
-	ret := &manager.Status{
-		Ok: true,
+	return nil
+}
+
+// sessionOptions converts the join options from the wire, nil joins with the defaults
+func sessionOptions(o *manager.JoinOptions) session.Options {
+	return session.Options{
+		MicOn:         o.GetMicOn(),
+		CameraOn:      o.GetCameraOn(),
+		DisplayName:   o.GetDisplayName(),
+		Windowed:      o.GetWindowMode() == manager.WindowMode_WINDOWED,
+		StayOnPrejoin: o.GetStayOnPrejoin(),
 	}
+}
 
-	return ret, nil
+// toMeetEvent converts a session event to the wire format, the session event types are numbered like the proto enum
+func toMeetEvent(man *manager.Meet, ev session.Event) *manager.MeetEvent {
+	return &manager.MeetEvent{
+		Type:    manager.MeetEventType(ev.Type),
+		Uri:     man.Uri,
+		Message: ev.Message,
+		Time:    timestamppb.New(ev.Time),
+	}
 }
 
 // GRPCServer is launched via a go routine
-func GRPCServer(ctx context.Context, config *utils.Config, serverReady chan<- struct{}) {
-	port := config.Port
-	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+func GRPCServer(ctx context.Context, config *utils.Config, cron *tasks.Cron, serverReady chan<- struct{}) {
+	lis, err := listen(config)
 	if err != nil {
-		logrus.Errorf("could not listen to port %d: %v", port, err)
+		logrus.Errorf("could not listen on %s: %v", listenAddress(config), err)
 		panic(err)
 	}
 
-	logrus.Infof("GRPCServer starting localhost:%d\n", port)
+	logrus.Infof("GRPCServer starting %s tls: %t\n", listenAddress(config), config.TLS != nil)
 
-	s := grpc.NewServer()
-	manager.RegisterOpenMeetUrlServer(s, server{})
+	opts, err := serverOptions(config)
+	if err != nil {
+		logrus.Errorf("could not configure the server: %v", err)
+		panic(err)
+	}
+
+	policy, err := ParsePolicy(config.ConcurrencyPolicy)
+	if err != nil {
+		logrus.Errorf("could not configure the server: %v", err)
+		panic(err)
+	}
+
+	s := grpc.NewServer(opts...)
+	srv := newServer(cron, policy)
+	manager.RegisterOpenMeetUrlServer(s, srv)
+	manager.RegisterSchedulerServer(s, srv)
+	manager.RegisterDispatcherServer(s, srv)
+	healthpb.RegisterHealthServer(s, daemonHealth.srv)
+	reflection.Register(s)
+
+	if config.HTTPListen != "" {
+		go serveGateway(ctx, config, srv)
+	}
+
+	go srv.probeBrowser(ctx, session.Probe)
+
+	if cron != nil {
+		metrics.WatchSchedule(cron.Len)
+	}
+	if config.MetricsListen != "" {
+		go metrics.Serve(ctx, config.MetricsListen)
+	}
 
 	go func(s *grpc.Server, lis net.Listener) {
 		err := s.Serve(lis)
@@ -106,5 +347,6 @@ func GRPCServer(ctx context.Context, config *utils.Config, serverReady chan<- st
 
 	<-ctx.Done()
 	logrus.Infoln("GRPC-Server is shutting down")
+	daemonHealth.srv.Shutdown()
 	s.GracefulStop()
 }
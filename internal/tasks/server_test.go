@@ -4,8 +4,14 @@ import (
 	"context"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/dathan/go-grpc-video-call-manager/pkg/manager"
+	"github.com/dathan/go-grpc-video-call-manager/pkg/session"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
 )
 
 func Test_server_OpenMeetUrl(t *testing.T) {
@@ -36,15 +42,118 @@ func Test_server_OpenMeetUrl(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			s := server{
 				UnimplementedOpenMeetUrlServer: tt.fields.UnimplementedOpenMeetUrlServer,
+				meetings:                       newRegistry(),
+				browser:                        newBrowser(PolicyReject),
 			}
 			got, err := s.OpenMeetUrl(tt.args.c, tt.args.man)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("server.OpenMeetUrl() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
+			if got.SessionId == "" {
+				t.Errorf("server.OpenMeetUrl() returned no session id")
+			}
+			// the session id is random, the rest of the status is not
+			got.SessionId = ""
 			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("server.OpenMeetUrl() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
+
+func Test_toMeetEvent(t *testing.T) {
+	man := &manager.Meet{Uri: "https://meet.google.com/frt-ywwd-epk"}
+	for et := session.EventLaunchingBrowser; et <= session.EventPrejoin; et++ {
+		got := toMeetEvent(man, session.Event{Type: et, Time: time.Now()})
+		if got.Type.String() != et.String() {
+			t.Errorf("toMeetEvent(%s) = %s", et, got.Type)
+		}
+		if got.Uri != man.Uri {
+			t.Errorf("toMeetEvent(%s) uri = %s, want %s", et, got.Uri, man.Uri)
+		}
+	}
+}
+
+func Test_sessionOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		in   *manager.JoinOptions
+		want session.Options
+	}{
+		{"nil joins with the defaults", nil, session.Options{}},
+		{"camera on windowed", &manager.JoinOptions{CameraOn: true, WindowMode: manager.WindowMode_WINDOWED}, session.Options{CameraOn: true, Windowed: true}},
+		{"guest at the pre-join screen", &manager.JoinOptions{MicOn: true, DisplayName: "Bot", StayOnPrejoin: true}, session.Options{MicOn: true, DisplayName: "Bot", StayOnPrejoin: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sessionOptions(tt.in); got != tt.want {
+				t.Errorf("sessionOptions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_server_LeaveMeeting(t *testing.T) {
+	s := newServer(nil, PolicyReject)
+	if _, err := s.LeaveMeeting(context.Background(), &manager.SessionRequest{SessionId: "missing"}); err == nil {
+		t.Errorf("server.LeaveMeeting() of an unknown session should fail")
+	}
+
+	m := s.meetings.add("https://meet.google.com/frt-ywwd-epk", &session.Session{})
+	got, err := s.ListActiveMeetings(context.Background(), &emptypb.Empty{})
+	if err != nil {
+		t.Fatalf("server.ListActiveMeetings() error = %v", err)
+	}
+	if len(got.Meetings) != 1 || got.Meetings[0].SessionId != m.id {
+		t.Fatalf("server.ListActiveMeetings() = %v, want session %s", got, m.id)
+	}
+
+	if _, err := s.LeaveMeeting(context.Background(), &manager.SessionRequest{SessionId: m.id}); err != nil {
+		t.Errorf("server.LeaveMeeting() error = %v", err)
+	}
+	if !m.isLeaving() {
+		t.Errorf("server.LeaveMeeting() did not mark the meeting as leaving")
+	}
+}
+
+// stalledStream is an event stream whose reader only takes the first event once it is released
+type stalledStream struct {
+	grpc.ServerStream
+	release chan struct{}
+	sent    []*manager.MeetEvent
+}
+
+func (s *stalledStream) Context() context.Context { return context.Background() }
+func (s *stalledStream) Send(ev *manager.MeetEvent) error {
+	<-s.release
+	s.sent = append(s.sent, ev)
+	return nil
+}
+
+func Test_streamEvents_slowReader(t *testing.T) {
+	r := newRegistry()
+	m := r.add("https://meet.google.com/frt-ywwd-epk", &session.Session{})
+	m.publish(&manager.MeetEvent{Type: manager.MeetEventType_LAUNCHING_BROWSER})
+
+	stream := &stalledStream{release: make(chan struct{})}
+	errc := make(chan error, 1)
+	go func() { errc <- streamEvents(stream, m) }()
+
+	// the reader is stuck on the first event while the meeting fills its buffer and fails
+	time.Sleep(50 * time.Millisecond)
+	for i := 0; i < 32; i++ {
+		m.publish(&manager.MeetEvent{Type: manager.MeetEventType_PAGE_LOADED})
+	}
+	m.publish(&manager.MeetEvent{Type: manager.MeetEventType_ERROR, Error: errorDetail(session.ErrLoginTimeout)})
+	r.remove(m)
+	close(stream.release)
+
+	err := <-errc
+	if status.Code(err) != codes.DeadlineExceeded {
+		t.Errorf("streamEvents() error = %v, want the status of the login timeout", err)
+	}
+	if last := stream.sent[len(stream.sent)-1]; last.Type != manager.MeetEventType_ERROR {
+		t.Errorf("last event sent = %s, want ERROR", last.Type)
+	}
+}
@@ -0,0 +1,74 @@
+package tasks
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/dathan/go-grpc-video-call-manager/internal/utils"
+	"github.com/dathan/go-grpc-video-call-manager/pkg/manager"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// recordSpans installs a tracer provider that keeps the spans in memory for the test
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	rec := tracetest.NewSpanRecorder()
+	prevProvider, prevPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(prevProvider)
+		otel.SetTextMapPropagator(prevPropagator)
+	})
+	return rec
+}
+
+func Test_traceAcrossGRPC(t *testing.T) {
+	rec := recordSpans(t)
+
+	path := filepath.Join(t.TempDir(), "meet.sock")
+	config := &utils.Config{Listen: "unix://" + path, Backend: "unix://" + path}
+	lis, err := listen(config)
+	if err != nil {
+		t.Fatalf("listen() error = %v", err)
+	}
+
+	opts, err := serverOptions(config)
+	if err != nil {
+		t.Fatalf("serverOptions() error = %v", err)
+	}
+	s := grpc.NewServer(opts...)
+	manager.RegisterOpenMeetUrlServer(s, newServer(nil, PolicyReject))
+	go s.Serve(lis)
+	defer s.Stop()
+
+	conn, err := Dial(config)
+	if err != nil {
+		t.Fatalf("Dial() error = %v", err)
+	}
+	defer conn.Close()
+
+	ctx, span := tracer.Start(context.Background(), "meet.execute")
+	if _, err := manager.NewOpenMeetUrlClient(conn).ListActiveMeetings(ctx, &emptypb.Empty{}); err != nil {
+		t.Fatalf("ListActiveMeetings() error = %v", err)
+	}
+	span.End()
+
+	var server sdktrace.ReadOnlySpan
+	for _, sp := range rec.Ended() {
+		if sp.Name() == "manager.OpenMeetUrl/ListActiveMeetings" && sp.SpanKind().String() == "server" {
+			server = sp
+		}
+	}
+	if server == nil {
+		t.Fatalf("no server span was recorded")
+	}
+	if server.SpanContext().TraceID() != span.SpanContext().TraceID() {
+		t.Errorf("server span trace = %s, want the caller's %s", server.SpanContext().TraceID(), span.SpanContext().TraceID())
+	}
+}
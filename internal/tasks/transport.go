@@ -0,0 +1,142 @@
+package tasks
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dathan/go-grpc-video-call-manager/internal/utils"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// unixScheme prefixes a listen or backend address that is a unix domain socket
+const unixScheme = "unix://"
+
+// listenAddress is where the grpc server listens, the port on every interface unless listen is set
+func listenAddress(config *utils.Config) string {
+	if config.Listen != "" {
+		return config.Listen
+	}
+	return fmt.Sprintf(":%d", config.Port)
+}
+
+// listen opens the tcp or unix socket listener for the grpc server
+func listen(config *utils.Config) (net.Listener, error) {
+	addr := listenAddress(config)
+	if !strings.HasPrefix(addr, unixScheme) {
+		return net.Listen("tcp", addr)
+	}
+
+	mode := os.FileMode(0600)
+	if config.SocketMode != "" {
+		m, err := strconv.ParseUint(config.SocketMode, 8, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid socket_mode %q: %w", config.SocketMode, err)
+		}
+		mode = os.FileMode(m)
+	}
+
+	path := strings.TrimPrefix(addr, unixScheme)
+	if err := removeStaleSocket(path); err != nil {
+		return nil, err
+	}
+
+	lis, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.Chmod(path, mode); err != nil {
+		lis.Close()
+		return nil, err
+	}
+
+	return lis, nil
+}
+
+// removeStaleSocket removes a socket left behind by a daemon that did not shut down cleanly
+func removeStaleSocket(path string) error {
+	fi, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if fi.Mode()&os.ModeSocket == 0 {
+		return fmt.Errorf("%s exists and is not a socket", path)
+	}
+
+	// someone answering means another daemon is running
+	if conn, err := net.DialTimeout("unix", path, time.Second); err == nil {
+		conn.Close()
+		return fmt.Errorf("%s is in use by another process", path)
+	}
+
+	logrus.Infof("Removing stale socket %s", path)
+	return os.Remove(path)
+}
+
+// serverOptions secures the grpc server according to the config
+func serverOptions(config *utils.Config) ([]grpc.ServerOption, error) {
+	// picks up the trace of the caller, a no-op when tracing is off
+	opts := []grpc.ServerOption{grpc.StatsHandler(otelgrpc.NewServerHandler())}
+
+	if config.TLS != nil {
+		c, err := utils.ServerTLSConfig(config.TLS)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, grpc.Creds(credentials.NewTLS(c)))
+	}
+
+	token, err := config.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	if token == "" {
+		logrus.Warn("No auth_token is configured, any caller can use the grpc api")
+	} else {
+		auth := tokenAuth{token: token}
+		opts = append(opts, grpc.ChainUnaryInterceptor(auth.unary), grpc.ChainStreamInterceptor(auth.stream))
+	}
+
+	return opts, nil
+}
+
+// Dial connects to the browser server at the configured backend, host:port or unix:///path/to.sock
+func Dial(config *utils.Config) (*grpc.ClientConn, error) {
+	creds := insecure.NewCredentials()
+
+	if config.TLS != nil {
+		c, err := utils.ClientTLSConfig(config.TLS)
+		if err != nil {
+			return nil, err
+		}
+		creds = credentials.NewTLS(c)
+	}
+	opts := []grpc.DialOption{
+		grpc.WithTransportCredentials(creds),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()), // carries the trace to the server
+	}
+
+	token, err := config.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	if token != "" {
+		opts = append(opts, grpc.WithPerRPCCredentials(tokenCredentials{token: token}))
+	}
+
+	return grpc.Dial(config.Backend, opts...)
+}
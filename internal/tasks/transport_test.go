@@ -0,0 +1,74 @@
+package tasks
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dathan/go-grpc-video-call-manager/internal/utils"
+	"github.com/dathan/go-grpc-video-call-manager/pkg/manager"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func Test_listen_unix(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "meet.sock")
+
+	// a daemon that died left its socket behind
+	stale, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatalf("net.Listen() error = %v", err)
+	}
+	stale.(*net.UnixListener).SetUnlinkOnClose(false)
+	stale.Close()
+
+	config := &utils.Config{Listen: "unix://" + path, Backend: "unix://" + path, SocketMode: "0660"}
+	lis, err := listen(config)
+	if err != nil {
+		t.Fatalf("listen() over a stale socket error = %v", err)
+	}
+
+	fi, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("os.Stat() error = %v", err)
+	}
+	if fi.Mode().Perm() != 0660 {
+		t.Errorf("socket mode = %o, want 660", fi.Mode().Perm())
+	}
+
+	// a live socket is not taken over
+	if _, err := listen(config); err == nil {
+		t.Errorf("listen() on a socket in use should fail")
+	}
+
+	s := grpc.NewServer()
+	manager.RegisterOpenMeetUrlServer(s, newServer(nil, PolicyReject))
+	go s.Serve(lis)
+	defer s.Stop()
+
+	conn, err := Dial(config)
+	if err != nil {
+		t.Fatalf("Dial() error = %v", err)
+	}
+	defer conn.Close()
+
+	if _, err := manager.NewOpenMeetUrlClient(conn).ListActiveMeetings(context.Background(), &emptypb.Empty{}); err != nil {
+		t.Errorf("ListActiveMeetings() over the unix socket error = %v", err)
+	}
+}
+
+func Test_listen_notSocket(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "meet.sock")
+	if err := os.WriteFile(path, []byte("keep me"), 0600); err != nil {
+		t.Fatalf("os.WriteFile() error = %v", err)
+	}
+
+	if _, err := listen(&utils.Config{Listen: "unix://" + path}); err == nil {
+		t.Errorf("listen() over a regular file should fail")
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("listen() removed a file that is not a socket")
+	}
+}
@@ -0,0 +1,66 @@
+// Package tracing sets up the opentelemetry exporter the daemon sends its spans to.
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dathan/go-grpc-video-call-manager/internal/utils"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+)
+
+const serviceName = "go-grpc-video-call-manager"
+
+// Setup installs the exporter from the config as the global tracer provider.
+// Tracing is off when c is nil, the returned func flushes the spans on shutdown.
+func Setup(ctx context.Context, c *utils.TracingConfig) (func(context.Context) error, error) {
+	noop := func(context.Context) error { return nil }
+	if c == nil {
+		return noop, nil
+	}
+
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch c.Exporter {
+	case "stdout":
+		exporter, err = stdouttrace.New(stdouttrace.WithPrettyPrint())
+	case "otlp", "":
+		endpoint := c.Endpoint
+		if endpoint == "" {
+			endpoint = "localhost:4317"
+		}
+		opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(endpoint)}
+		if c.Insecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		exporter, err = otlptracegrpc.New(ctx, opts...)
+	default:
+		return noop, fmt.Errorf("unknown tracing exporter %q, want stdout or otlp", c.Exporter)
+	}
+	if err != nil {
+		return noop, err
+	}
+
+	ratio := c.SampleRatio
+	if ratio <= 0 {
+		ratio = 1
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio))),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(serviceName))),
+	)
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	logrus.Infof("Tracing to %s %s sampling %.2f", c.Exporter, c.Endpoint, ratio)
+	return tp.Shutdown, nil
+}
@@ -0,0 +1,115 @@
+package utils
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// CertKey is a certificate with its private key
+type CertKey struct {
+	Cert *x509.Certificate
+	Key  *ecdsa.PrivateKey
+	DER  []byte
+}
+
+// GenerateCA creates a self signed certificate authority for the daemon
+func GenerateCA(name string, validFor time.Duration) (*CertKey, error) {
+	tmpl, err := certTemplate(name, validFor)
+	if err != nil {
+		return nil, err
+	}
+	tmpl.IsCA = true
+	tmpl.BasicConstraintsValid = true
+	tmpl.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	return sign(tmpl, tmpl, key, key)
+}
+
+// Issue signs a certificate for name. hosts become the DNS and IP names of a server cert,
+// a client cert is only good for authenticating to the server.
+func (ca *CertKey) Issue(name string, hosts []string, client bool, validFor time.Duration) (*CertKey, error) {
+	tmpl, err := certTemplate(name, validFor)
+	if err != nil {
+		return nil, err
+	}
+	tmpl.KeyUsage = x509.KeyUsageDigitalSignature
+
+	if client {
+		tmpl.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
+	} else {
+		tmpl.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
+		for _, h := range hosts {
+			if ip := net.ParseIP(h); ip != nil {
+				tmpl.IPAddresses = append(tmpl.IPAddresses, ip)
+			} else {
+				tmpl.DNSNames = append(tmpl.DNSNames, h)
+			}
+		}
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	return sign(tmpl, ca.Cert, key, ca.Key)
+}
+
+// WritePEM writes the cert to dir/name.pem and the key to dir/name-key.pem
+func (ck *CertKey) WritePEM(dir, name string) error {
+	certOut := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ck.DER})
+	if err := os.WriteFile(filepath.Join(dir, name+".pem"), certOut, 0644); err != nil {
+		return err
+	}
+
+	der, err := x509.MarshalECPrivateKey(ck.Key)
+	if err != nil {
+		return err
+	}
+
+	keyOut := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})
+	return os.WriteFile(filepath.Join(dir, name+"-key.pem"), keyOut, 0600)
+}
+
+func certTemplate(name string, validFor time.Duration) (*x509.Certificate, error) {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: name, Organization: []string{"go-grpc-video-call-manager"}},
+		NotBefore:    now.Add(-time.Hour), // allow for clock skew between machines
+		NotAfter:     now.Add(validFor),
+	}, nil
+}
+
+func sign(tmpl, parent *x509.Certificate, key, parentKey *ecdsa.PrivateKey) (*CertKey, error) {
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		return nil, err
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CertKey{Cert: cert, Key: key, DER: der}, nil
+}
@@ -0,0 +1,94 @@
+package utils
+
+import (
+	"crypto/tls"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// handshake runs a tls handshake between the two configs and exchanges a byte so the
+// server's verdict on the client cert reaches the client
+func handshake(server, client *tls.Config) error {
+	lis, err := tls.Listen("tcp", "127.0.0.1:0", server)
+	if err != nil {
+		return err
+	}
+	defer lis.Close()
+
+	go func() {
+		conn, err := lis.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		conn.Write([]byte{1})
+	}()
+
+	conn, err := tls.Dial("tcp", lis.Addr().String(), client)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	_, err = conn.Read(make([]byte, 1))
+	return err
+}
+
+func TestMutualTLS(t *testing.T) {
+	dir := t.TempDir()
+
+	ca, err := GenerateCA("test CA", time.Hour)
+	if err != nil {
+		t.Fatalf("GenerateCA() error = %v", err)
+	}
+	server, err := ca.Issue("server", []string{"localhost", "127.0.0.1"}, false, time.Hour)
+	if err != nil {
+		t.Fatalf("Issue(server) error = %v", err)
+	}
+	client, err := ca.Issue("client", nil, true, time.Hour)
+	if err != nil {
+		t.Fatalf("Issue(client) error = %v", err)
+	}
+
+	for name, ck := range map[string]*CertKey{"ca": ca, "server": server, "client": client} {
+		if err := ck.WritePEM(dir, name); err != nil {
+			t.Fatalf("WritePEM(%s) error = %v", name, err)
+		}
+	}
+
+	conf := &TLSConfig{
+		CAFile:         filepath.Join(dir, "ca.pem"),
+		CertFile:       filepath.Join(dir, "server.pem"),
+		KeyFile:        filepath.Join(dir, "server-key.pem"),
+		ClientAuth:     true,
+		ClientCertFile: filepath.Join(dir, "client.pem"),
+		ClientKeyFile:  filepath.Join(dir, "client-key.pem"),
+		ServerName:     "localhost",
+	}
+
+	sc, err := ServerTLSConfig(conf)
+	if err != nil {
+		t.Fatalf("ServerTLSConfig() error = %v", err)
+	}
+	cc, err := ClientTLSConfig(conf)
+	if err != nil {
+		t.Fatalf("ClientTLSConfig() error = %v", err)
+	}
+
+	if err := handshake(sc, cc); err != nil {
+		t.Errorf("mutual tls handshake error = %v", err)
+	}
+
+	// a client without a cert is turned away
+	anon := *conf
+	anon.ClientCertFile = ""
+	anon.ClientKeyFile = ""
+	ac, err := ClientTLSConfig(&anon)
+	if err != nil {
+		t.Fatalf("ClientTLSConfig() error = %v", err)
+	}
+	if err := handshake(sc, ac); err == nil {
+		t.Errorf("handshake without a client cert should fail")
+	}
+}
@@ -1,12 +1,48 @@
 package utils
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
 
 type Config struct {
-	Email       string `json:"email"`
-	Credentials []byte `json:"credentials"`
-	Backend     string `json:"backend"`
-	Port        int    `json:"port"`
+	Email       string     `json:"email"`
+	Credentials []byte     `json:"credentials"`
+	Backend     string     `json:"backend"`
+	Port        int        `json:"port"`
+	Listen      string     `json:"listen"`      // host:port or unix:///path/to.sock, all interfaces on port when empty
+	SocketMode  string     `json:"socket_mode"` // octal permissions of the unix socket, 0600 when empty
+	HTTPListen  string     `json:"http_listen"` // host:port of the http/json gateway, off when empty
+	TLS         *TLSConfig `json:"tls"`         // plaintext when not set
+	// shared secret the grpc api requires as a bearer token, auth_token_file is read when auth_token is empty
+	AuthToken     string `json:"auth_token"`
+	AuthTokenFile string `json:"auth_token_file"`
+	// how the scheduler joins, the first rule matching the meeting summary changes the options it sets
+	JoinDefaults JoinOptions `json:"join_defaults"`
+	JoinRules    []JoinRule  `json:"join_rules"`
+	// what the browser server does with a join while another meeting has the browser: reject (the default), queue or replace
+	ConcurrencyPolicy string `json:"concurrency_policy"`
+	// host:port of the prometheus /metrics endpoint, off when empty
+	MetricsListen string `json:"metrics_listen"`
+	// opentelemetry exporter, tracing is off when not set
+	Tracing *TracingConfig `json:"tracing"`
+	// json lines file the join history is appended to, history.jsonl in the working directory when empty
+	HistoryFile string `json:"history_file"`
+	// run as a browser agent of another scheduler instead of polling the calendar
+	Agent *AgentConfig `json:"agent"`
+	// the first route matching a meeting picks the agents that join it, the backend joins the rest
+	Routes []Route `json:"routes"`
+	// where the meetings come from, merged in this order, the google calendar of credentials.json when empty
+	Providers []ProviderConfig `json:"providers"`
+	// how far past the end of today the calendars are read, a duration like 12h, the next 24 hours when empty
+	Lookahead string `json:"lookahead"`
 }
 
 func LoadConfig(paths []string) (*Config, error) {
@@ -25,3 +61,232 @@ func LoadConfig(paths []string) (*Config, error) {
 
 	return c, nil
 }
+
+// Token returns the shared secret for the grpc api, empty when auth is off
+func (c *Config) Token() (string, error) {
+	if c.AuthToken != "" || c.AuthTokenFile == "" {
+		return c.AuthToken, nil
+	}
+
+	b, err := os.ReadFile(c.AuthTokenFile)
+	if err != nil {
+		return "", err
+	}
+
+	token := strings.TrimSpace(string(b))
+	if token == "" {
+		return "", fmt.Errorf("auth token file %s is empty", c.AuthTokenFile)
+	}
+	return token, nil
+}
+
+// UsesGoogle is true when a provider reads a google calendar, the only one that needs credentials.json
+func (c *Config) UsesGoogle() bool {
+	if len(c.Providers) == 0 {
+		return true
+	}
+	for _, pc := range c.Providers {
+		if pc.Type == "" || pc.Type == "google" {
+			return true
+		}
+	}
+	return false
+}
+
+// Window returns the range the calendars are read for, the rest of today plus the lookahead
+func (c *Config) Window(now time.Time) (time.Time, time.Time) {
+	if c.Lookahead == "" {
+		return now, now.Add(24 * time.Hour)
+	}
+
+	d, err := time.ParseDuration(c.Lookahead)
+	if err == nil && d < 0 {
+		err = errors.New("must not be negative")
+	}
+	if err != nil {
+		logrus.Warnf("Ignoring lookahead %q: %v", c.Lookahead, err)
+		return now, now.Add(24 * time.Hour)
+	}
+
+	y, m, day := now.Date()
+	return now, time.Date(y, m, day+1, 0, 0, 0, 0, now.Location()).Add(d)
+}
+
+// JoinOptions is how to join a meeting, the zero value joins muted with the camera off in fullscreen
+type JoinOptions struct {
+	MicOn         bool   `json:"mic_on"`
+	CameraOn      bool   `json:"camera_on"`
+	DisplayName   string `json:"display_name"`
+	Windowed      bool   `json:"windowed"`
+	StayOnPrejoin bool   `json:"stay_on_prejoin"`
+}
+
+// JoinRule applies its options to the meetings whose summary matches the regular expression
+type JoinRule struct {
+	Match   string          `json:"match"`
+	Options JoinRuleOptions `json:"options"`
+}
+
+// JoinRuleOptions are the join options a rule sets, the ones it leaves out keep the defaults
+type JoinRuleOptions struct {
+	MicOn         *bool   `json:"mic_on"`
+	CameraOn      *bool   `json:"camera_on"`
+	DisplayName   *string `json:"display_name"`
+	Windowed      *bool   `json:"windowed"`
+	StayOnPrejoin *bool   `json:"stay_on_prejoin"`
+}
+
+// over returns the options with the ones the rule sets replaced
+func (r JoinRuleOptions) over(o JoinOptions) JoinOptions {
+	if r.MicOn != nil {
+		o.MicOn = *r.MicOn
+	}
+	if r.CameraOn != nil {
+		o.CameraOn = *r.CameraOn
+	}
+	if r.DisplayName != nil {
+		o.DisplayName = *r.DisplayName
+	}
+	if r.Windowed != nil {
+		o.Windowed = *r.Windowed
+	}
+	if r.StayOnPrejoin != nil {
+		o.StayOnPrejoin = *r.StayOnPrejoin
+	}
+	return o
+}
+
+// JoinOptionsFor returns the defaults with the options of the first rule matching the summary applied
+func (c *Config) JoinOptionsFor(summary string) JoinOptions {
+	for _, r := range c.JoinRules {
+		re, err := regexp.Compile(r.Match)
+		if err != nil {
+			logrus.Warnf("Ignoring join rule %q: %v", r.Match, err)
+			continue
+		}
+		if re.MatchString(summary) {
+			return r.Options.over(c.JoinDefaults)
+		}
+	}
+	return c.JoinDefaults
+}
+
+// ProviderConfig is a calendar the meetings are read from
+type ProviderConfig struct {
+	Type string `json:"type"` // google, ics, caldav or graph
+	Name string `json:"name"` // what routes call an ics feed, the url when empty, or a graph calendar, outlook when empty
+	URL  string `json:"url"`  // the ics feed, an http(s) url or a file path, or the caldav server
+	// caldav basic auth, password_file is read when password is empty, for an app password kept out of config.json
+	Username     string `json:"username"`
+	Password     string `json:"password"`
+	PasswordFile string `json:"password_file"`
+	// the google calendar ids to read, primary when empty, or the caldav calendars by display name or path,
+	// all of them when empty
+	Calendars []string `json:"calendars"`
+	// read every calendar in the google calendar list instead
+	Subscribed bool `json:"subscribed"`
+	// the google group addresses the user is invited through, and the calendar ids whose events are joined
+	// without an invite. The user or one of the groups has to be invited on every other calendar.
+	Groups        []string `json:"groups"`
+	JoinUninvited []string `json:"join_uninvited"`
+	// the azure app registration graph signs in with, the common tenant when empty, the token is cached in
+	// token_file, graph-token.json when empty
+	ClientID  string `json:"client_id"`
+	Tenant    string `json:"tenant"`
+	TokenFile string `json:"token_file"`
+}
+
+// Secret returns the password of the provider, empty when there is none
+func (pc ProviderConfig) Secret() (string, error) {
+	if pc.Password != "" || pc.PasswordFile == "" {
+		return pc.Password, nil
+	}
+
+	b, err := os.ReadFile(pc.PasswordFile)
+	if err != nil {
+		return "", err
+	}
+
+	password := strings.TrimSpace(string(b))
+	if password == "" {
+		return "", fmt.Errorf("password file %s is empty", pc.PasswordFile)
+	}
+	return password, nil
+}
+
+// AgentConfig is how a browser agent registers with the scheduler
+type AgentConfig struct {
+	Name         string            `json:"name"`      // the hostname when empty
+	Scheduler    string            `json:"scheduler"` // host:port or unix:///path/to.sock of the scheduler, dialed like the backend
+	Advertise    string            `json:"advertise"` // the address the scheduler dials to reach this agent
+	Labels       map[string]string `json:"labels"`
+	Capabilities []string          `json:"capabilities"`
+}
+
+// Route sends the meetings it matches to agents, every field that is set has to match
+type Route struct {
+	Calendar string `json:"calendar"` // the calendar id the meeting is on
+	Match    string `json:"match"`    // regexp on the summary
+	Room     string `json:"room"`     // regexp on the room resources booked for the meeting
+	// the agents to try by name in order, or else every agent carrying the labels
+	Agents       []string          `json:"agents"`
+	Labels       map[string]string `json:"labels"`
+	Capabilities []string          `json:"capabilities"` // the agent has to have all of them
+}
+
+// RouteFor returns the first route matching the meeting, nil when the backend should join it
+func (c *Config) RouteFor(calendar, summary string, rooms []string) *Route {
+	for i, r := range c.Routes {
+		if r.Calendar != "" && r.Calendar != calendar {
+			continue
+		}
+		if r.Match != "" && !matches(r.Match, summary) {
+			continue
+		}
+		if r.Room != "" && !matchesAny(r.Room, rooms) {
+			continue
+		}
+		return &c.Routes[i]
+	}
+	return nil
+}
+
+// matchesAny is true when one of the values matches the regexp
+func matchesAny(expr string, values []string) bool {
+	for _, v := range values {
+		if matches(expr, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// matches is false for a regexp that does not compile
+func matches(expr, s string) bool {
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		logrus.Warnf("Ignoring rule %q: %v", expr, err)
+		return false
+	}
+	return re.MatchString(s)
+}
+
+// TracingConfig picks where the spans go
+type TracingConfig struct {
+	Exporter    string  `json:"exporter"`     // stdout or otlp
+	Endpoint    string  `json:"endpoint"`     // host:port of the otlp grpc collector, localhost:4317 when empty
+	Insecure    bool    `json:"insecure"`     // talk to the collector without tls
+	SampleRatio float64 `json:"sample_ratio"` // share of the traces to keep, all of them when 0
+}
+
+// TLSConfig secures the grpc connection between the scheduler and the browser server.
+// The daemon is both, so the server and the client side are configured together.
+type TLSConfig struct {
+	CAFile         string `json:"ca_file"`     // verifies the other end, system roots when empty
+	CertFile       string `json:"cert_file"`   // the server cert
+	KeyFile        string `json:"key_file"`    // the server key
+	ClientAuth     bool   `json:"client_auth"` // the server requires a client cert signed by the ca
+	ClientCertFile string `json:"client_cert_file"`
+	ClientKeyFile  string `json:"client_key_file"`
+	ServerName     string `json:"server_name"` // the name the client expects in the server cert
+}
@@ -0,0 +1,118 @@
+package utils
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestConfig_JoinOptionsFor(t *testing.T) {
+	c := &Config{}
+	err := json.Unmarshal([]byte(`{
+		"join_defaults": {"display_name": "Meet Bot", "windowed": true},
+		"join_rules": [
+			{"match": "(", "options": {"mic_on": true}},
+			{"match": "(?i)1:1", "options": {"camera_on": true}},
+			{"match": "(?i)standup", "options": {"windowed": false, "display_name": ""}},
+			{"match": "(?i)1:1|standup", "options": {"stay_on_prejoin": true}}
+		]
+	}`), c)
+	if err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+
+	// broken rules are ignored, a rule keeps the defaults it does not set
+	tests := []struct {
+		summary string
+		want    JoinOptions
+	}{
+		{"Dathan / Sam 1:1", JoinOptions{CameraOn: true, DisplayName: "Meet Bot", Windowed: true}},
+		{"Daily Standup", JoinOptions{}},
+		{"All hands", JoinOptions{DisplayName: "Meet Bot", Windowed: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.summary, func(t *testing.T) {
+			if got := c.JoinOptionsFor(tt.summary); got != tt.want {
+				t.Errorf("JoinOptionsFor(%q) = %+v, want %+v", tt.summary, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfig_RouteFor(t *testing.T) {
+	c := &Config{
+		Routes: []Route{
+			{Room: "(?i)boardroom", Agents: []string{"boardroom"}},
+			{Calendar: "team@example.com", Match: "(?i)standup", Labels: map[string]string{"site": "office"}},
+			{Match: "(?i)interview", Agents: []string{"laptop"}},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		calendar string
+		summary  string
+		rooms    []string
+		want     int // index of the route, -1 for none
+	}{
+		{"room", "primary", "All hands", []string{"Lobby", "HQ-2-Boardroom (12)"}, 0},
+		{"calendar and summary", "team@example.com", "Daily Standup", nil, 1},
+		{"summary on another calendar", "primary", "Daily Standup", nil, -1},
+		{"summary", "primary", "Interview: Sam", nil, 2},
+		{"nothing matches", "primary", "1:1", []string{"Lobby"}, -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := c.RouteFor(tt.calendar, tt.summary, tt.rooms)
+			switch {
+			case tt.want < 0 && got != nil:
+				t.Errorf("RouteFor() = %+v, want none", got)
+			case tt.want >= 0 && got != &c.Routes[tt.want]:
+				t.Errorf("RouteFor() = %+v, want %+v", got, c.Routes[tt.want])
+			}
+		})
+	}
+}
+
+func TestConfig_Window(t *testing.T) {
+	now := time.Date(2024, 3, 5, 15, 30, 0, 0, time.FixedZone("EST", -5*3600))
+
+	tests := []struct {
+		lookahead string
+		want      time.Time
+	}{
+		{"", now.Add(24 * time.Hour)},
+		{"0s", time.Date(2024, 3, 6, 0, 0, 0, 0, now.Location())},
+		{"12h", time.Date(2024, 3, 6, 12, 0, 0, 0, now.Location())},
+		{"-1h", now.Add(24 * time.Hour)},
+		{"tomorrow", now.Add(24 * time.Hour)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.lookahead, func(t *testing.T) {
+			from, to := (&Config{Lookahead: tt.lookahead}).Window(now)
+			if !from.Equal(now) || !to.Equal(tt.want) {
+				t.Errorf("Window() = %s, %s want %s, %s", from, to, now, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfig_UsesGoogle(t *testing.T) {
+	tests := []struct {
+		name      string
+		providers []ProviderConfig
+		want      bool
+	}{
+		{"default", nil, true},
+		{"google", []ProviderConfig{{Type: "ics", URL: "feed.ics"}, {Type: "google"}}, true},
+		{"untyped", []ProviderConfig{{Name: "work"}}, true},
+		{"others", []ProviderConfig{{Type: "ics", URL: "feed.ics"}, {Type: "caldav"}, {Type: "graph"}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := (&Config{Providers: tt.providers}).UsesGoogle(); got != tt.want {
+				t.Errorf("UsesGoogle() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package utils
 
 import (
 	"os"
+	"path/filepath"
 )
 
 func GetFileContents(paths []string) ([]byte, error) {
@@ -15,3 +16,18 @@ func GetFileContents(paths []string) ([]byte, error) {
 	// Return nil and an error if the file is not found in any of the paths
 	return nil, os.ErrNotExist
 }
+
+// SearchPaths returns where the commands look for the file called name, relative to the working directory
+func SearchPaths(name string) ([]string, error) {
+	pwd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+
+	return []string{
+		filepath.Join(pwd, name),                                           // current directory
+		filepath.Join(pwd, "..", "conf", name),                             // conf directory
+		filepath.Join(pwd, "conf", name),                                   // conf directory
+		filepath.Join(pwd, "..", "cmd", "launch_google_meet_chrome", name), // ../cmd/launch_google_meet_chrome directory
+	}, nil
+}
@@ -0,0 +1,347 @@
+package calendar
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/dathan/go-grpc-video-call-manager/internal/utils"
+)
+
+// CalDAVProvider reads the meetings of the calendars of a CalDAV account, Nextcloud or Radicale for example
+type CalDAVProvider struct {
+	URL       string   // the dav root, the principal or a calendar
+	Username  string   // basic auth, left out when empty
+	Password  string   // the password or an app password
+	Calendars []string // display names or paths of the calendars to read, every calendar when empty
+	Email     string   // meetings this attendee declined are left out
+	Client    *http.Client
+
+	decisions []Decision // why each event of the last Meetings call was kept or dropped
+}
+
+// NewCalDAVProvider returns the provider of a caldav entry of the providers in config.json
+func NewCalDAVProvider(config *utils.Config, pc utils.ProviderConfig) (*CalDAVProvider, error) {
+	password, err := pc.Secret()
+	if err != nil {
+		return nil, err
+	}
+	return &CalDAVProvider{
+		URL:       pc.URL,
+		Username:  pc.Username,
+		Password:  password,
+		Calendars: pc.Calendars,
+		Email:     config.Email,
+	}, nil
+}
+
+// davCalendar is a calendar collection on the server
+type davCalendar struct {
+	href string
+	name string
+}
+
+// Meetings returns the meetings of the calendars that are not over by from and start before to
+func (p *CalDAVProvider) Meetings(ctx context.Context, from, to time.Time) (MeetItems, error) {
+	p.decisions = nil
+	cals, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var lists []MeetItems
+	for _, cal := range cals {
+		meetings, err := p.query(ctx, cal, from, to)
+		if err != nil {
+			return nil, err
+		}
+		lists = append(lists, meetings)
+	}
+	return Merge(lists...), nil
+}
+
+// Decisions returns why each event of the last Meetings call was kept or dropped
+func (p *CalDAVProvider) Decisions() []Decision {
+	return p.decisions
+}
+
+// multistatus is the answer to PROPFIND and REPORT
+type multistatus struct {
+	Responses []davResponse `xml:"DAV: response"`
+}
+
+type davResponse struct {
+	Href     string `xml:"DAV: href"`
+	Propstat []struct {
+		Prop   davProp `xml:"DAV: prop"`
+		Status string  `xml:"DAV: status"`
+	} `xml:"DAV: propstat"`
+}
+
+type davProp struct {
+	DisplayName  string `xml:"DAV: displayname"`
+	ResourceType struct {
+		Calendar *struct{} `xml:"urn:ietf:params:xml:ns:caldav calendar"`
+	} `xml:"DAV: resourcetype"`
+	Principal *davHref `xml:"DAV: current-user-principal"`
+	Home      *davHref `xml:"urn:ietf:params:xml:ns:caldav calendar-home-set"`
+	Data      string   `xml:"urn:ietf:params:xml:ns:caldav calendar-data"`
+}
+
+type davHref struct {
+	Href string `xml:"DAV: href"`
+}
+
+// found returns the properties the server has, the ones it reported missing are left out
+func (r davResponse) found() davProp {
+	var ret davProp
+	for _, ps := range r.Propstat {
+		if !strings.Contains(ps.Status, " 200") {
+			continue
+		}
+		pr := ps.Prop
+		if pr.DisplayName != "" {
+			ret.DisplayName = pr.DisplayName
+		}
+		if pr.ResourceType.Calendar != nil {
+			ret.ResourceType.Calendar = pr.ResourceType.Calendar
+		}
+		if pr.Principal != nil {
+			ret.Principal = pr.Principal
+		}
+		if pr.Home != nil {
+			ret.Home = pr.Home
+		}
+		if pr.Data != "" {
+			ret.Data = pr.Data
+		}
+	}
+	return ret
+}
+
+const propfindBody = `<?xml version="1.0" encoding="utf-8"?>
+<d:propfind xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav">
+  <d:prop>
+    <d:resourcetype/>
+    <d:displayname/>
+    <d:current-user-principal/>
+    <c:calendar-home-set/>
+  </d:prop>
+</d:propfind>`
+
+// discover finds the calendars to read: the url when it is a calendar, or else the calendars in the
+// home set of the principal
+func (p *CalDAVProvider) discover(ctx context.Context) ([]davCalendar, error) {
+	ms, err := p.do(ctx, "PROPFIND", p.URL, "0", propfindBody)
+	if err != nil {
+		return nil, err
+	}
+	if len(ms.Responses) == 0 {
+		return nil, fmt.Errorf("%s: no properties", p.URL)
+	}
+	prop := ms.Responses[0].found()
+
+	if prop.ResourceType.Calendar != nil {
+		return []davCalendar{{href: p.URL, name: calendarName(prop.DisplayName, p.URL)}}, nil
+	}
+
+	if prop.Home == nil {
+		if prop.Principal == nil {
+			return nil, fmt.Errorf("%s: no calendar home or principal", p.URL)
+		}
+		principal, err := p.resolve(prop.Principal.Href)
+		if err != nil {
+			return nil, err
+		}
+		ms, err := p.do(ctx, "PROPFIND", principal, "0", propfindBody)
+		if err != nil {
+			return nil, err
+		}
+		if len(ms.Responses) == 0 || ms.Responses[0].found().Home == nil {
+			return nil, fmt.Errorf("%s: the principal has no calendar home", principal)
+		}
+		prop = ms.Responses[0].found()
+	}
+
+	home, err := p.resolve(prop.Home.Href)
+	if err != nil {
+		return nil, err
+	}
+	ms, err = p.do(ctx, "PROPFIND", home, "1", propfindBody)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret []davCalendar
+	for _, r := range ms.Responses {
+		prop := r.found()
+		if prop.ResourceType.Calendar == nil {
+			continue // the home itself, address books and the like
+		}
+		href, err := p.resolve(r.Href)
+		if err != nil {
+			return nil, err
+		}
+		cal := davCalendar{href: href, name: calendarName(prop.DisplayName, r.Href)}
+		if p.wants(cal, r.Href) {
+			ret = append(ret, cal)
+		}
+	}
+
+	if len(ret) == 0 {
+		return nil, fmt.Errorf("%s: no calendars to read in %s", p.URL, home)
+	}
+	return ret, nil
+}
+
+// wants is true when the calendar is one of the configured ones, every calendar is when none is
+func (p *CalDAVProvider) wants(cal davCalendar, href string) bool {
+	if len(p.Calendars) == 0 {
+		return true
+	}
+	for _, c := range p.Calendars {
+		if c == cal.name || strings.Trim(c, "/") == strings.Trim(href, "/") || strings.Trim(c, "/") == path.Base(strings.Trim(href, "/")) {
+			return true
+		}
+	}
+	return false
+}
+
+// calendarName is the display name, the last part of the path when it has none
+func calendarName(display, href string) string {
+	if display != "" {
+		return display
+	}
+	u, err := url.Parse(href)
+	if err == nil {
+		href = u.Path
+	}
+	return path.Base(strings.Trim(href, "/"))
+}
+
+const reportBody = `<?xml version="1.0" encoding="utf-8"?>
+<c:calendar-query xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav">
+  <d:prop>
+    <c:calendar-data/>
+  </d:prop>
+  <c:filter>
+    <c:comp-filter name="VCALENDAR">
+      <c:comp-filter name="VEVENT">
+        <c:time-range start="%s" end="%s"/>
+      </c:comp-filter>
+    </c:comp-filter>
+  </c:filter>
+</c:calendar-query>`
+
+// query asks for the events with an occurrence between from and to, the series are expanded here
+// since not every server supports expand
+func (p *CalDAVProvider) query(ctx context.Context, cal davCalendar, from, to time.Time) (MeetItems, error) {
+	const utcFormat = "20060102T150405Z"
+	body := fmt.Sprintf(reportBody, from.UTC().Format(utcFormat), to.UTC().Format(utcFormat))
+	ms, err := p.do(ctx, "REPORT", cal.href, "1", body)
+	if err != nil {
+		return nil, err
+	}
+
+	var lists []MeetItems
+	for _, r := range ms.Responses {
+		data := r.found().Data
+		if data == "" {
+			continue
+		}
+		meetings, decisions, err := readICal(strings.NewReader(data), cal.name, p.Email, from, to)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", r.Href, err)
+		}
+		lists = append(lists, meetings)
+		p.decisions = append(p.decisions, decisions...)
+	}
+	return Merge(lists...), nil
+}
+
+// maxRedirects is how many redirects a webdav request follows, .well-known/caldav is usually one
+const maxRedirects = 5
+
+// do sends a webdav request and reads the multistatus answer. A redirect is followed with the same method
+// and body, the http client would turn PROPFIND and REPORT into a GET. The url the provider was given is
+// replaced by the one it redirects to so the hrefs of the server resolve against it.
+func (p *CalDAVProvider) do(ctx context.Context, method, target, depth, body string) (*multistatus, error) {
+	client := p.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	noFollow := *client
+	noFollow.CheckRedirect = func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }
+
+	for redirects := 0; ; redirects++ {
+		req, err := http.NewRequestWithContext(ctx, method, target, bytes.NewBufferString(body))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", `application/xml; charset="utf-8"`)
+		req.Header.Set("Depth", depth)
+		if p.Username != "" && p.sameHost(req.URL) {
+			req.SetBasicAuth(p.Username, p.Password)
+		}
+
+		resp, err := noFollow.Do(req)
+		if err != nil {
+			return nil, err
+		}
+
+		loc, _ := resp.Location()
+		if resp.StatusCode/100 == 3 && loc != nil && redirects < maxRedirects {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+			if target == p.URL {
+				p.URL = loc.String()
+			}
+			target = loc.String()
+			continue
+		}
+
+		return readMultistatus(resp, method, target)
+	}
+}
+
+// readMultistatus reads and closes the answer to a webdav request
+func readMultistatus(resp *http.Response, method, target string) (*multistatus, error) {
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusMultiStatus {
+		io.Copy(io.Discard, resp.Body)
+		return nil, fmt.Errorf("%s %s: %s", method, target, resp.Status)
+	}
+
+	ms := &multistatus{}
+	if err := xml.NewDecoder(resp.Body).Decode(ms); err != nil {
+		return nil, fmt.Errorf("%s %s: %w", method, target, err)
+	}
+	return ms, nil
+}
+
+// sameHost tells if u is on the server of the provider's url, the password is not sent to another one
+func (p *CalDAVProvider) sameHost(u *url.URL) bool {
+	base, err := url.Parse(p.URL)
+	return err == nil && strings.EqualFold(base.Host, u.Host)
+}
+
+// resolve turns an href of the server into a url
+func (p *CalDAVProvider) resolve(href string) (string, error) {
+	base, err := url.Parse(p.URL)
+	if err != nil {
+		return "", err
+	}
+	ref, err := url.Parse(href)
+	if err != nil {
+		return "", err
+	}
+	return base.ResolveReference(ref).String(), nil
+}
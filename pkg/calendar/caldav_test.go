@@ -0,0 +1,221 @@
+package calendar
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	standupICS = `BEGIN:VCALENDAR
+VERSION:2.0
+PRODID:-//Nextcloud calendar//EN
+BEGIN:VEVENT
+UID:standup@nextcloud
+DTSTART;TZID=Europe/Berlin:20240304T090000
+DTEND;TZID=Europe/Berlin:20240304T091500
+RRULE:FREQ=DAILY;COUNT=3
+SUMMARY:Standup
+LOCATION:https://meet.google.com/frt-ywwd-epk
+END:VEVENT
+END:VCALENDAR
+`
+	reviewICS = `BEGIN:VCALENDAR
+VERSION:2.0
+PRODID:-//Nextcloud calendar//EN
+BEGIN:VEVENT
+UID:review@nextcloud
+DTSTART:20240305T130000Z
+DTEND:20240305T140000Z
+SUMMARY:Design review
+DESCRIPTION:Join: https://meet.google.com/abc-defg-hij
+END:VEVENT
+END:VCALENDAR
+`
+	dentistICS = `BEGIN:VCALENDAR
+VERSION:2.0
+PRODID:-//Nextcloud calendar//EN
+BEGIN:VEVENT
+UID:dentist@nextcloud
+DTSTART:20240305T160000Z
+DTEND:20240305T170000Z
+SUMMARY:Dentist
+LOCATION:Main St 1
+END:VEVENT
+BEGIN:VEVENT
+UID:family@nextcloud
+DTSTART:20240306T180000Z
+DTEND:20240306T183000Z
+SUMMARY:Family call
+LOCATION:https://meet.google.com/xyz-abcd-efg
+END:VEVENT
+END:VCALENDAR
+`
+)
+
+// davServer is an in-process stand-in for a caldav server with a work and a personal calendar
+type davServer struct {
+	t         *testing.T
+	calendars map[string][]string // path to the calendar objects
+	reports   []string            // the time ranges asked for
+}
+
+func (s *davServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/.well-known/caldav" {
+		http.Redirect(w, r, "/remote.php/dav/", http.StatusMovedPermanently)
+		return
+	}
+
+	if user, password, ok := r.BasicAuth(); !ok || user != "dathan" || password != "app-password" {
+		w.Header().Set("WWW-Authenticate", `Basic realm="dav"`)
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var body bytes.Buffer
+	body.ReadFrom(r.Body)
+
+	var responses []string
+	switch {
+	case r.Method == "PROPFIND" && r.URL.Path == "/remote.php/dav/":
+		responses = append(responses, davProps(r.URL.Path, `<d:current-user-principal><d:href>/remote.php/dav/principals/users/dathan/</d:href></d:current-user-principal>`))
+	case r.Method == "PROPFIND" && r.URL.Path == "/remote.php/dav/principals/users/dathan/":
+		responses = append(responses, davProps(r.URL.Path, `<c:calendar-home-set><d:href>/remote.php/dav/calendars/dathan/</d:href></c:calendar-home-set>`))
+	case r.Method == "PROPFIND" && r.URL.Path == "/remote.php/dav/calendars/dathan/":
+		if r.Header.Get("Depth") != "1" {
+			s.t.Errorf("PROPFIND of the home has Depth %q, want 1", r.Header.Get("Depth"))
+		}
+		responses = append(responses,
+			davProps(r.URL.Path, `<d:resourcetype><d:collection/></d:resourcetype>`),
+			davProps(r.URL.Path+"work/", `<d:resourcetype><d:collection/><c:calendar/></d:resourcetype><d:displayname>Work</d:displayname>`),
+			davProps(r.URL.Path+"personal/", `<d:resourcetype><d:collection/><c:calendar/></d:resourcetype>`),
+			davProps(r.URL.Path+"contacts/", `<d:resourcetype><d:collection/><card:addressbook xmlns:card="urn:ietf:params:xml:ns:carddav"/></d:resourcetype>`),
+		)
+	case r.Method == "PROPFIND" && s.calendars[r.URL.Path] != nil:
+		responses = append(responses, davProps(r.URL.Path, `<d:resourcetype><d:collection/><c:calendar/></d:resourcetype><d:displayname>Work</d:displayname>`))
+	case r.Method == "REPORT" && s.calendars[r.URL.Path] != nil:
+		var query struct {
+			Range struct {
+				Start string `xml:"start,attr"`
+				End   string `xml:"end,attr"`
+			} `xml:"filter>comp-filter>comp-filter>time-range"`
+		}
+		if err := xml.Unmarshal(body.Bytes(), &query); err != nil {
+			s.t.Errorf("REPORT body %s: %v", body.String(), err)
+		}
+		s.reports = append(s.reports, query.Range.Start+"/"+query.Range.End)
+		for i, data := range s.calendars[r.URL.Path] {
+			var escaped strings.Builder
+			xml.EscapeText(&escaped, []byte(data))
+			responses = append(responses, davProps(fmt.Sprintf("%s%d.ics", r.URL.Path, i), `<c:calendar-data>`+escaped.String()+`</c:calendar-data>`))
+		}
+	default:
+		http.NotFound(w, r)
+		return
+	}
+
+	w.Header().Set("Content-Type", `application/xml; charset="utf-8"`)
+	w.WriteHeader(http.StatusMultiStatus)
+	fmt.Fprintf(w, `<?xml version="1.0" encoding="utf-8"?>
+<d:multistatus xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav">%s</d:multistatus>`, strings.Join(responses, ""))
+}
+
+// davProps is a response with the props found and a displayname the server does not have
+func davProps(href, props string) string {
+	return `<d:response><d:href>` + href + `</d:href>` +
+		`<d:propstat><d:prop>` + props + `</d:prop><d:status>HTTP/1.1 200 OK</d:status></d:propstat>` +
+		`<d:propstat><d:prop><d:displayname/></d:prop><d:status>HTTP/1.1 404 Not Found</d:status></d:propstat>` +
+		`</d:response>`
+}
+
+func TestCalDAVProvider(t *testing.T) {
+	s := &davServer{t: t, calendars: map[string][]string{
+		"/remote.php/dav/calendars/dathan/work/":     {standupICS, reviewICS},
+		"/remote.php/dav/calendars/dathan/personal/": {dentistICS},
+	}}
+	ts := httptest.NewServer(s)
+	defer ts.Close()
+
+	from := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)
+	to := from.Add(7 * 24 * time.Hour)
+
+	tests := []struct {
+		name      string
+		url       string
+		calendars []string
+		want      []meeting
+	}{
+		{"every calendar of the account", ts.URL + "/remote.php/dav/", nil, []meeting{
+			{"Standup", "2024-03-04T08:00:00Z"},
+			{"Standup", "2024-03-05T08:00:00Z"},
+			{"Design review", "2024-03-05T13:00:00Z"},
+			{"Standup", "2024-03-06T08:00:00Z"},
+			{"Family call", "2024-03-06T18:00:00Z"},
+		}},
+		{"well-known redirect", ts.URL + "/.well-known/caldav", nil, []meeting{
+			{"Standup", "2024-03-04T08:00:00Z"},
+			{"Standup", "2024-03-05T08:00:00Z"},
+			{"Design review", "2024-03-05T13:00:00Z"},
+			{"Standup", "2024-03-06T08:00:00Z"},
+			{"Family call", "2024-03-06T18:00:00Z"},
+		}},
+		{"by path", ts.URL + "/remote.php/dav/", []string{"personal"}, []meeting{
+			{"Family call", "2024-03-06T18:00:00Z"},
+		}},
+		{"a calendar url", ts.URL + "/remote.php/dav/calendars/dathan/work/", nil, []meeting{
+			{"Standup", "2024-03-04T08:00:00Z"},
+			{"Standup", "2024-03-05T08:00:00Z"},
+			{"Design review", "2024-03-05T13:00:00Z"},
+			{"Standup", "2024-03-06T08:00:00Z"},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s.reports = nil
+			p := &CalDAVProvider{URL: tt.url, Username: "dathan", Password: "app-password", Calendars: tt.calendars}
+			got, err := p.Meetings(context.Background(), from, to)
+			if err != nil {
+				t.Fatalf("Meetings() error = %v", err)
+			}
+			sameMeetings(t, got, tt.want)
+
+			for _, r := range s.reports {
+				if r != "20240304T000000Z/20240311T000000Z" {
+					t.Errorf("REPORT time-range = %s, want the window in utc", r)
+				}
+			}
+		})
+	}
+
+	p := &CalDAVProvider{URL: ts.URL + "/remote.php/dav/", Username: "dathan", Password: "app-password", Calendars: []string{"Work"}}
+	got, err := p.Meetings(context.Background(), from, to)
+	if err != nil {
+		t.Fatalf("Meetings() error = %v", err)
+	}
+	if len(got) != 4 || got[0].Calendar != "Work" || got[0].Uri != "https://meet.google.com/frt-ywwd-epk" {
+		t.Errorf("Meetings() = %+v, want the meetings of Work with their meet links", got)
+	}
+
+	p.Calendars = nil
+	if _, err := p.Meetings(context.Background(), from, to); err != nil {
+		t.Fatalf("Meetings() error = %v", err)
+	}
+	hasDecision(t, p.Decisions(), "Design review", "2024-03-05T13:00:00Z", true, "on the calendar")
+	hasDecision(t, p.Decisions(), "Dentist", "2024-03-05T16:00:00Z", false, "no conference link")
+
+	p.Password = "wrong"
+	if _, err := p.Meetings(context.Background(), from, to); err == nil || !strings.Contains(err.Error(), "401") {
+		t.Errorf("Meetings() with a wrong password error = %v, want 401", err)
+	}
+
+	p = &CalDAVProvider{URL: ts.URL + "/remote.php/dav/", Username: "dathan", Password: "app-password", Calendars: []string{"Holidays"}}
+	if _, err := p.Meetings(context.Background(), from, to); err == nil {
+		t.Errorf("Meetings() without any of the calendars asked for should fail")
+	}
+}
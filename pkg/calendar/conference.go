@@ -0,0 +1,47 @@
+package calendar
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// conferenceLinks finds the join links of the usual video call services in free text
+var conferenceLinks = regexp.MustCompile(`https://(?:` +
+	`meet\.google\.com/[a-z]+-[a-z]+-[a-z]+` +
+	`|(?:[\w-]+\.)*zoom\.us/(?:j|my|w)/[^\s"'<>]+` +
+	`|teams\.microsoft\.com/l/meetup-join/[^\s"'<>]+` +
+	`|(?:[\w-]+\.)*webex\.com/(?:meet/|join/|[\w.-]+/j\.php)[^\s"'<>]*` +
+	`)`)
+
+// conferenceLink returns the first google meet link in the texts, the first video call link of another
+// service when there is none. The texts are looked at in order.
+func conferenceLink(texts ...string) string {
+	var other string
+	for _, t := range texts {
+		for _, link := range conferenceLinks.FindAllString(t, -1) {
+			link = strings.TrimRight(link, ".,;)]")
+			if joinable(link) {
+				return link
+			}
+			if other == "" {
+				other = link
+			}
+		}
+	}
+	return other
+}
+
+// joinable tells if the browser can join the link, it only knows the google meet pages
+func joinable(link string) bool {
+	return strings.HasPrefix(link, "https://meet.google.com/")
+}
+
+// unsupported is the reason a meeting with a link of another service is dropped
+func unsupported(link string) string {
+	host := link
+	if u, err := url.Parse(link); err == nil {
+		host = u.Host
+	}
+	return "unsupported conference link " + host
+}
@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/dathan/go-grpc-video-call-manager/internal/utils"
@@ -20,10 +22,19 @@ import (
 
 var TOKEN *oauth2.Token
 
+// ErrNoUpcomingEvents is returned when the calendar has nothing scheduled
+var ErrNoUpcomingEvents = errors.New("No Upcoming events")
+
 // service to get the urls
 type CalService struct {
 	callersEmail string
 	config       *utils.Config
+	calendars    []string               // the calendar ids to read, primary when empty
+	subscribed   bool                   // read every calendar of the calendar list instead
+	groups       []string               // the group addresses the user is invited through
+	uninvited    []string               // the calendar ids whose events are joined without an invite
+	decisions    []Decision             // why each event of the last Meetings call was kept or dropped
+	caches       map[string]*eventCache // the events of each calendar as of the last sync
 }
 
 // MeetItem is a structure comtaining the pertinate meeting info
@@ -32,6 +43,11 @@ type MeetItem struct {
 	Summary   string
 	StartTime time.Time
 	EndTime   time.Time
+	Calendar  string   // the id of the calendar the event is on
+	EventID   string   // the id of the event, the same on every calendar it is on
+	Rooms     []string // the room resources booked for the meeting
+	Organizer string   // the email of the organizer
+	Response  string   // how the user answered: organizer, accepted, tentative or none, declined meetings are left out
 }
 
 // Collection
@@ -46,10 +62,19 @@ func NewCalService(config *utils.Config) *CalService {
 
 }
 
-// GetUpcomingMeetings returns a list of meetings to join for the day
+// GetUpcomingMeetings returns a list of meetings to join in the window of the config
 func (em *CalService) GetUpcomingMeetings() (MeetItems, error) {
+	from, to := em.config.Window(time.Now())
+	meetings, err := em.Meetings(context.Background(), from, to)
+	if err == nil && len(meetings) == 0 {
+		return meetings, ErrNoUpcomingEvents
+	}
+	return meetings, err
+}
+
+// Meetings returns the google meet events of the calendars between from and to, to is a day after from when zero
+func (em *CalService) Meetings(ctx context.Context, from, to time.Time) (MeetItems, error) {
 
-	meetings := MeetItems{}
 	credentials := em.config.Credentials
 
 	// If modifying these scopes, delete your previously saved token.json.
@@ -61,72 +86,233 @@ func (em *CalService) GetUpcomingMeetings() (MeetItems, error) {
 	}
 
 	client := getClient(config)
-	srv, err := calendar.NewService(context.Background(), option.WithHTTPClient(client))
+	srv, err := calendar.NewService(ctx, option.WithHTTPClient(client))
 	if err != nil {
 		log.Errorf("Unable to retrieve Calendar client: %v", err)
 		return nil, err
 	}
 
-	t := time.Now().Format(time.RFC3339)
-	events, err := srv.Events.List("primary").ShowDeleted(false).
-		SingleEvents(true).TimeMin(t).MaxResults(10).OrderBy("startTime").Do()
+	return em.meetings(ctx, srv, from, to)
+}
+
+// meetings reads every calendar, an event on several of them is kept once, from the first calendar it is on
+func (em *CalService) meetings(ctx context.Context, srv *calendar.Service, from, to time.Time) (MeetItems, error) {
+	if to.IsZero() {
+		to = from.Add(24 * time.Hour)
+	}
+	em.decisions = nil
+
+	ids, err := em.calendarIDs(ctx, srv)
 	if err != nil {
-		log.Errorf("Unable to retrieve next ten of the user's events: %v", err)
+		log.Errorf("Unable to list the user's calendars: %v", err)
 		return nil, err
 	}
 
-	if len(events.Items) == 0 {
-		return meetings, errors.New("No Upcoming events")
+	meetings := MeetItems{}
+	seen := map[string]string{}
+	var errs []error
+	for _, id := range ids {
+		items, err := em.calendarMeetings(ctx, srv, id, from, to, seen)
+		if err != nil {
+			log.Errorf("Unable to read calendar %s: %v", id, err)
+			errs = append(errs, err)
+			continue
+		}
+		meetings = append(meetings, items...)
+	}
+	if len(errs) > 0 && len(errs) == len(ids) {
+		return nil, errs[0]
 	}
 
-	for _, item := range events.Items {
+	sort.SliceStable(meetings, func(i, j int) bool { return meetings[i].StartTime.Before(meetings[j].StartTime) })
+	return meetings, nil
+}
+
+// Decisions returns why each event of the last Meetings call was kept or dropped
+func (em *CalService) Decisions() []Decision {
+	return em.decisions
+}
 
-		if item.ConferenceData != nil && item.ConferenceData.ConferenceSolution != nil && item.ConferenceData.ConferenceSolution.Name == "Google Meet" {
-			for _, entry := range item.ConferenceData.EntryPoints {
-				if entry.Uri != "" && entry.EntryPointType == "video" && (item.Organizer.Email == em.callersEmail || em.checkGoogleEventAttendies(item.Attendees)) {
+// calendarIDs returns the calendars to read, the primary calendar is called primary in the calendar list too
+func (em *CalService) calendarIDs(ctx context.Context, srv *calendar.Service) ([]string, error) {
+	if !em.subscribed {
+		if len(em.calendars) == 0 {
+			return []string{"primary"}, nil
+		}
+		return em.calendars, nil
+	}
 
-					date := item.Start.DateTime
-					if date == "" {
-						date = item.Start.Date
-					}
+	var ids []string
+	err := srv.CalendarList.List().Pages(ctx, func(list *calendar.CalendarList) error {
+		for _, entry := range list.Items {
+			if entry.Primary {
+				ids = append(ids, "primary")
+				continue
+			}
+			ids = append(ids, entry.Id)
+		}
+		return nil
+	})
+	return ids, err
+}
 
-					st, _ := time.Parse(time.RFC3339, date)
+// calendarMeetings returns the google meet events of a calendar between from and to. The user has to be the
+// organizer or invited, themselves or through one of the groups, unless the calendar joins uninvited events.
+// seen has the calendar each event was kept from.
+func (em *CalService) calendarMeetings(ctx context.Context, srv *calendar.Service, id string, from, to time.Time, seen map[string]string) (MeetItems, error) {
 
-					date = item.End.DateTime
-					if date == "" {
-						date = item.End.Date
-					}
+	meetings := MeetItems{}
 
-					et, _ := time.Parse(time.RFC3339, date)
-					mi := MeetItem{
-						Uri:       entry.Uri,
-						StartTime: st,
-						EndTime:   et,
-						Summary:   item.Summary,
-					}
+	events, err := em.sync(ctx, srv, id, from, to)
+	if err != nil {
+		log.Errorf("Unable to retrieve the user's events: %v", err)
+		return nil, err
+	}
 
-					meetings = append(meetings, mi)
-				}
-			}
+	for _, item := range events {
+		start, end := eventTime(item.Start), eventTime(item.End)
+		if !end.After(from) || !start.Before(to) {
+			continue // the cache reads ahead of the window
 		}
 
+		mi, reason := em.meeting(item, id, seen)
+		em.decisions = append(em.decisions, Decision{Calendar: id, EventID: item.Id, Summary: item.Summary, Start: start, Kept: mi != nil, Reason: reason})
+
+		if mi != nil {
+			seen[item.Id] = id
+			meetings = append(meetings, *mi)
+		}
 	}
 
 	return meetings, nil
 }
 
-// checks to see if the attendee is self
-func (cs *CalService) checkGoogleEventAttendies(attendies []*calendar.EventAttendee) bool {
+// meeting returns the meeting to join for the event or why there is none, and why it is joined
+func (em *CalService) meeting(item *calendar.Event, id string, seen map[string]string) (*MeetItem, string) {
+	if item.Start == nil || item.Start.DateTime == "" {
+		return nil, "all day"
+	}
 
-	for _, attendee := range attendies {
-		//TODO get this from a config of who the caller is
-		if attendee.Email == cs.callersEmail && attendee.ResponseStatus != "declined" {
+	uri := ""
+	if item.ConferenceData != nil && item.ConferenceData.ConferenceSolution != nil && item.ConferenceData.ConferenceSolution.Name == "Google Meet" {
+		for _, entry := range item.ConferenceData.EntryPoints {
+			if entry.Uri != "" && entry.EntryPointType == "video" {
+				uri = entry.Uri
+				break
+			}
+		}
+	}
+	if uri == "" {
+		return nil, "no google meet link"
+	}
+
+	response := em.response(item)
+	reason := "invited"
+	switch invitee := em.invitee(item.Attendees); {
+	case response == "declined":
+		return nil, "declined"
+	case response == "organizer":
+		reason = "organizer"
+	case invitee == "" && !em.joinsUninvited(id):
+		return nil, "not invited"
+	case invitee == "":
+		reason = "on the calendar"
+	case invitee != em.callersEmail:
+		reason = "invited as " + invitee
+	}
+	if other, ok := seen[item.Id]; ok {
+		return nil, "already on " + other
+	}
+
+	st, _ := time.Parse(time.RFC3339, item.Start.DateTime)
+	var et time.Time
+	if item.End != nil {
+		et, _ = time.Parse(time.RFC3339, item.End.DateTime)
+	}
+	return &MeetItem{
+		Uri:       uri,
+		StartTime: st,
+		EndTime:   et,
+		Summary:   item.Summary,
+		Calendar:  id,
+		EventID:   item.Id,
+		Rooms:     rooms(item.Attendees),
+		Organizer: organizer(item),
+		Response:  response,
+	}, reason
+}
+
+// organizer is the email of the organizer, empty when the event has none
+func organizer(item *calendar.Event) string {
+	if item.Organizer == nil {
+		return ""
+	}
+	return item.Organizer.Email
+}
+
+// rooms returns the names of the room resources invited to the event
+func rooms(attendees []*calendar.EventAttendee) []string {
+	var ret []string
+	for _, a := range attendees {
+		if !a.Resource {
+			continue
+		}
+		name := a.DisplayName
+		if name == "" {
+			name = a.Email
+		}
+		ret = append(ret, name)
+	}
+	return ret
+}
+
+// response is how the caller answered the invite
+func (cs *CalService) response(item *calendar.Event) string {
+	if organizer(item) == cs.callersEmail {
+		return "organizer"
+	}
+	for _, attendee := range item.Attendees {
+		if attendee.Email != cs.callersEmail {
+			continue
+		}
+		switch attendee.ResponseStatus {
+		case "accepted", "tentative", "declined":
+			return attendee.ResponseStatus
+		}
+	}
+	return "none"
+}
+
+// joinsUninvited tells if the events of the calendar are joined without an invite, off unless configured
+func (cs *CalService) joinsUninvited(id string) bool {
+	for _, u := range cs.uninvited {
+		if u == id {
 			return true
 		}
 	}
 	return false
 }
 
+// invitee returns the address the user is invited as, themselves or one of the groups, empty when neither
+// is an attendee that did not decline
+func (cs *CalService) invitee(attendies []*calendar.EventAttendee) string {
+	ret := ""
+	for _, attendee := range attendies {
+		if attendee.ResponseStatus == "declined" {
+			continue
+		}
+		if attendee.Email == cs.callersEmail {
+			return attendee.Email
+		}
+		for _, g := range cs.groups {
+			if ret == "" && strings.EqualFold(attendee.Email, g) {
+				ret = g
+			}
+		}
+	}
+	return ret
+}
+
 // Retrieve a token, saves the token, then returns the generated client.
 func getClient(config *oauth2.Config) *http.Client {
 	// The file token.json stores the user's access and refresh tokens, and is
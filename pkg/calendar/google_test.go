@@ -0,0 +1,340 @@
+package calendar
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+	"google.golang.org/api/option"
+)
+
+// googleServer is an in-process stand-in for the calendar list and events of the google calendar api
+type googleServer struct {
+	t         *testing.T
+	list      []*calendar.CalendarListEntry
+	calendars map[string][]*calendar.Event // calendar id to its events, the ones missing answer 404
+	pageSize  int                          // events per page, all of them when 0
+	queries   []url.Values                 // the events queries asked
+	token     string                       // the sync token of the last page, the other ones answer 410 Gone
+	changes   map[string][]*calendar.Event // calendar id to the changes a sync with the token gets
+}
+
+func (s *googleServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/calendar/v3")
+	switch {
+	case path == "/users/me/calendarList":
+		s.write(w, &calendar.CalendarList{Items: s.list})
+	case strings.HasPrefix(path, "/calendars/") && strings.HasSuffix(path, "/events"):
+		id := strings.TrimSuffix(strings.TrimPrefix(path, "/calendars/"), "/events")
+		events, ok := s.calendars[id]
+		if !ok {
+			http.Error(w, `{"error":{"code":404,"message":"Not Found"}}`, http.StatusNotFound)
+			return
+		}
+		q := r.URL.Query()
+		s.queries = append(s.queries, q)
+
+		if st := q.Get("syncToken"); st != "" {
+			if q.Get("timeMin") != "" || q.Get("timeMax") != "" || q.Get("orderBy") != "" {
+				s.t.Errorf("events query = %v, a sync token cannot be asked with a time range or order", q)
+			}
+			if st != s.token {
+				http.Error(w, `{"error":{"code":410,"message":"Sync token is no longer valid, a full sync is required."}}`, http.StatusGone)
+				return
+			}
+			events = s.changes[id]
+		}
+
+		// the page token is the index of the first event of the page
+		first, _ := strconv.Atoi(q.Get("pageToken"))
+		page := &calendar.Events{Items: events[first:]}
+		if s.pageSize > 0 && len(page.Items) > s.pageSize {
+			page.Items = page.Items[:s.pageSize]
+			page.NextPageToken = strconv.Itoa(first + s.pageSize)
+		} else {
+			page.NextSyncToken = s.token
+		}
+		s.write(w, page)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (s *googleServer) write(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		s.t.Errorf("json.Encode() error = %v", err)
+	}
+}
+
+// newGoogleService returns a calendar service talking to the stand-in
+func newGoogleService(t *testing.T, ts *httptest.Server) *calendar.Service {
+	srv, err := calendar.NewService(context.Background(), option.WithEndpoint(ts.URL+"/calendar/v3/"), option.WithHTTPClient(ts.Client()))
+	if err != nil {
+		t.Fatalf("calendar.NewService() error = %v", err)
+	}
+	return srv
+}
+
+// meetEvent is a google meet event at start, the user answered the invite with status, not invited when empty
+func meetEvent(id, summary, start, organizer, status string) *calendar.Event {
+	st, _ := time.Parse(time.RFC3339, start)
+	e := &calendar.Event{
+		Id:        id,
+		Summary:   summary,
+		Start:     &calendar.EventDateTime{DateTime: st.Format(time.RFC3339)},
+		End:       &calendar.EventDateTime{DateTime: st.Add(30 * time.Minute).Format(time.RFC3339)},
+		Organizer: &calendar.EventOrganizer{Email: organizer},
+		Attendees: []*calendar.EventAttendee{{Email: organizer, ResponseStatus: "accepted"}},
+		ConferenceData: &calendar.ConferenceData{
+			ConferenceSolution: &calendar.ConferenceSolution{Name: "Google Meet"},
+			EntryPoints:        []*calendar.EntryPoint{{EntryPointType: "video", Uri: "https://meet.google.com/" + id}},
+		},
+	}
+	if status != "" {
+		e.Attendees = append(e.Attendees, &calendar.EventAttendee{Email: "dathan@example.com", ResponseStatus: status})
+	}
+	return e
+}
+
+func TestCalService_meetings(t *testing.T) {
+	const team, sam = "team@group.calendar.google.com", "sam@example.com"
+	standup := meetEvent("standup", "Team standup", "2024-03-05T14:00:00Z", "sam@example.com", "")
+	standup.Attendees = append(standup.Attendees, &calendar.EventAttendee{Email: "eng@example.com", ResponseStatus: "needsAction"})
+	s := &googleServer{
+		t: t,
+		list: []*calendar.CalendarListEntry{
+			{Id: "dathan@example.com", Primary: true},
+			{Id: team},
+			{Id: sam}, // subscribed to see when sam is free
+			{Id: "holidays@group.v.calendar.google.com"},
+		},
+		calendars: map[string][]*calendar.Event{
+			"primary": {
+				meetEvent("one", "1:1", "2024-03-05T15:00:00Z", "sam@example.com", "accepted"),
+				meetEvent("other", "Someone else's meeting", "2024-03-05T15:30:00Z", "sam@example.com", ""),
+				meetEvent("declined", "Declined", "2024-03-05T16:00:00Z", "sam@example.com", "declined"),
+				meetEvent("planning", "Planning", "2024-03-05T17:00:00Z", "sam@example.com", "needsAction"),
+			},
+			team: {
+				meetEvent("planning", "Planning", "2024-03-05T17:00:00Z", "sam@example.com", "needsAction"),
+				standup,
+				meetEvent("retro", "Retro", "2024-03-05T18:00:00Z", "sam@example.com", "declined"),
+			},
+			sam: {
+				meetEvent("interview", "Interview", "2024-03-05T16:30:00Z", "sam@example.com", ""),
+			},
+		},
+	}
+	ts := httptest.NewServer(s)
+	defer ts.Close()
+	srv := newGoogleService(t, ts)
+	from := time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		calendars  []string
+		subscribed bool
+		groups     []string
+		uninvited  []string
+		want       []meeting
+		calendar   []string // the calendar of each meeting
+	}{
+		{"primary", nil, false, nil, nil, []meeting{
+			{"1:1", "2024-03-05T15:00:00Z"},
+			{"Planning", "2024-03-05T17:00:00Z"},
+		}, []string{"primary", "primary"}},
+		{"calendar ids", []string{team, "primary"}, false, nil, nil, []meeting{
+			{"1:1", "2024-03-05T15:00:00Z"},
+			{"Planning", "2024-03-05T17:00:00Z"},
+		}, []string{"primary", team}},
+		{"invited as a group", []string{team, "primary"}, false, []string{"Eng@example.com"}, nil, []meeting{
+			{"Team standup", "2024-03-05T14:00:00Z"},
+			{"1:1", "2024-03-05T15:00:00Z"},
+			{"Planning", "2024-03-05T17:00:00Z"},
+		}, []string{team, "primary", team}},
+		{"subscribed", nil, true, []string{"eng@example.com"}, nil, []meeting{
+			{"Team standup", "2024-03-05T14:00:00Z"},
+			{"1:1", "2024-03-05T15:00:00Z"},
+			{"Planning", "2024-03-05T17:00:00Z"},
+		}, []string{team, "primary", "primary"}},
+		{"join uninvited", nil, true, nil, []string{team}, []meeting{
+			{"Team standup", "2024-03-05T14:00:00Z"},
+			{"1:1", "2024-03-05T15:00:00Z"},
+			{"Planning", "2024-03-05T17:00:00Z"},
+		}, []string{team, "primary", "primary"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cs := &CalService{callersEmail: "dathan@example.com", calendars: tt.calendars, subscribed: tt.subscribed, groups: tt.groups, uninvited: tt.uninvited}
+			got, err := cs.meetings(context.Background(), srv, from, from.Add(24*time.Hour))
+			if err != nil {
+				t.Fatalf("meetings() error = %v", err)
+			}
+			sameMeetings(t, got, tt.want)
+			for i, mi := range got {
+				if i < len(tt.calendar) && mi.Calendar != tt.calendar[i] {
+					t.Errorf("%s is on %s, want %s", mi.Summary, mi.Calendar, tt.calendar[i])
+				}
+			}
+			for _, d := range cs.Decisions() {
+				if d.EventID == "interview" && (d.Kept || d.Reason != "not invited") {
+					t.Errorf("decision on sam's interview = %q kept %t, want not invited", d.Reason, d.Kept)
+				}
+				if d.EventID == "standup" && d.Kept && len(tt.groups) > 0 && d.Reason != "invited as "+tt.groups[0] {
+					t.Errorf("decision on the standup = %q, want invited as the group", d.Reason)
+				}
+			}
+		})
+	}
+
+	cs := &CalService{callersEmail: "dathan@example.com", calendars: []string{"missing@example.com"}}
+	if _, err := cs.meetings(context.Background(), srv, from, from.Add(24*time.Hour)); err == nil {
+		t.Errorf("meetings() when no calendar can be read should fail")
+	}
+}
+
+func TestCalService_pages(t *testing.T) {
+	// a busy day: the meeting is behind more events than fit on a page
+	var events []*calendar.Event
+	at := time.Date(2024, 3, 5, 13, 0, 0, 0, time.UTC)
+	events = append(events, &calendar.Event{Id: "ooo", Summary: "Out of office", Start: &calendar.EventDateTime{Date: "2024-03-05"}, End: &calendar.EventDateTime{Date: "2024-03-06"}})
+	for i := 0; i < 11; i++ {
+		start := at.Add(time.Duration(i) * 10 * time.Minute)
+		events = append(events, &calendar.Event{
+			Id:      fmt.Sprintf("focus%d", i),
+			Summary: "Focus time",
+			Start:   &calendar.EventDateTime{DateTime: start.Format(time.RFC3339)},
+			End:     &calendar.EventDateTime{DateTime: start.Add(10 * time.Minute).Format(time.RFC3339)},
+		})
+	}
+	events = append(events,
+		meetEvent("declined", "Declined", "2024-03-05T15:00:00Z", "sam@example.com", "declined"),
+		meetEvent("other", "Someone else's meeting", "2024-03-05T15:30:00Z", "sam@example.com", ""),
+		meetEvent("review", "Design review", "2024-03-05T16:00:00Z", "dathan@example.com", ""),
+	)
+
+	s := &googleServer{t: t, calendars: map[string][]*calendar.Event{"primary": events}, pageSize: 4}
+	ts := httptest.NewServer(s)
+	defer ts.Close()
+
+	cs := &CalService{callersEmail: "dathan@example.com"}
+	from := time.Date(2024, 3, 5, 12, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 3, 6, 6, 0, 0, 0, time.UTC)
+	got, err := cs.meetings(context.Background(), newGoogleService(t, ts), from, to)
+	if err != nil {
+		t.Fatalf("meetings() error = %v", err)
+	}
+	sameMeetings(t, got, []meeting{{"Design review", "2024-03-05T16:00:00Z"}})
+
+	if len(s.queries) != 4 {
+		t.Errorf("read %d pages, want 4", len(s.queries))
+	}
+	for _, q := range s.queries {
+		// the full sync reads ahead as far again as the window so the next polls can stay incremental
+		if q.Get("timeMin") != "2024-03-05T12:00:00Z" || q.Get("timeMax") != "2024-03-07T00:00:00Z" {
+			t.Errorf("events query = %v, want twice the window", q)
+		}
+	}
+
+	reasons := map[string]string{}
+	kept := map[string]bool{}
+	for _, d := range cs.Decisions() {
+		reasons[d.EventID] = d.Reason
+		kept[d.EventID] = d.Kept
+	}
+	if len(cs.Decisions()) != len(events) {
+		t.Errorf("Decisions() has %d events, want every one of the %d read", len(cs.Decisions()), len(events))
+	}
+	for id, want := range map[string]string{
+		"ooo":      "all day",
+		"focus10":  "no google meet link",
+		"declined": "declined",
+		"other":    "not invited",
+		"review":   "organizer",
+	} {
+		if reasons[id] != want || kept[id] != (id == "review") {
+			t.Errorf("decision on %s = %q kept %t, want %q", id, reasons[id], kept[id], want)
+		}
+	}
+}
+
+func TestCalService_sync(t *testing.T) {
+	s := &googleServer{
+		t: t,
+		calendars: map[string][]*calendar.Event{"primary": {
+			meetEvent("one", "1:1", "2024-03-05T15:00:00Z", "dathan@example.com", ""),
+			meetEvent("two", "Planning", "2024-03-05T16:00:00Z", "dathan@example.com", ""),
+			meetEvent("three", "Retro", "2024-03-05T17:00:00Z", "dathan@example.com", ""),
+		}},
+		token: "t1",
+	}
+	ts := httptest.NewServer(s)
+	defer ts.Close()
+	srv := newGoogleService(t, ts)
+
+	cs := &CalService{callersEmail: "dathan@example.com"}
+	from := time.Date(2024, 3, 5, 12, 0, 0, 0, time.UTC)
+	poll := func(from time.Time, want []meeting) url.Values {
+		t.Helper()
+		got, err := cs.meetings(context.Background(), srv, from, from.Add(24*time.Hour))
+		if err != nil {
+			t.Fatalf("meetings() error = %v", err)
+		}
+		sameMeetings(t, got, want)
+		return s.queries[len(s.queries)-1]
+	}
+
+	if q := poll(from, []meeting{
+		{"1:1", "2024-03-05T15:00:00Z"},
+		{"Planning", "2024-03-05T16:00:00Z"},
+		{"Retro", "2024-03-05T17:00:00Z"},
+	}); q.Get("syncToken") != "" {
+		t.Errorf("first events query = %v, want a full sync", q)
+	}
+
+	// planning moved, the retro was cancelled and a demo was added
+	s.changes = map[string][]*calendar.Event{"primary": {
+		meetEvent("two", "Planning", "2024-03-05T18:00:00Z", "dathan@example.com", ""),
+		{Id: "three", Status: "cancelled"},
+		meetEvent("four", "Demo", "2024-03-05T19:00:00Z", "dathan@example.com", ""),
+	}}
+	if q := poll(from.Add(time.Hour), []meeting{
+		{"1:1", "2024-03-05T15:00:00Z"},
+		{"Planning", "2024-03-05T18:00:00Z"},
+		{"Demo", "2024-03-05T19:00:00Z"},
+	}); q.Get("syncToken") != "t1" || q.Get("showDeleted") != "true" {
+		t.Errorf("second events query = %v, want the changes since the first", q)
+	}
+
+	// the token expired, the calendar is read again
+	s.token = "t2"
+	s.calendars["primary"] = []*calendar.Event{
+		meetEvent("one", "1:1", "2024-03-05T15:00:00Z", "dathan@example.com", ""),
+		meetEvent("five", "Offsite", "2024-03-05T20:00:00Z", "dathan@example.com", ""),
+	}
+	before := len(s.queries)
+	if q := poll(from.Add(2*time.Hour), []meeting{
+		{"1:1", "2024-03-05T15:00:00Z"},
+		{"Offsite", "2024-03-05T20:00:00Z"},
+	}); len(s.queries) != before+2 || q.Get("syncToken") != "" || q.Get("timeMin") != "2024-03-05T14:00:00Z" {
+		t.Errorf("events queries after the token expired = %v, want a failed sync and a full one", s.queries[before:])
+	}
+
+	// once the window reaches past what the full sync read it is read again
+	s.changes = nil
+	if q := poll(from.Add(27*time.Hour), []meeting{}); q.Get("syncToken") != "" {
+		t.Errorf("events query past the full sync = %v, want a full sync", q)
+	}
+	if len(cs.caches["primary"].events) != 0 {
+		t.Errorf("cache has %d events, the ones that are over should be dropped", len(cs.caches["primary"].events))
+	}
+}
@@ -0,0 +1,322 @@
+package calendar
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/dathan/go-grpc-video-call-manager/internal/utils"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/microsoft"
+)
+
+// GraphProvider reads the meetings of a Microsoft 365 / Outlook calendar with the calendarView of Microsoft Graph
+type GraphProvider struct {
+	Calendar  string         // what routes call the calendar
+	BaseURL   string         // https://graph.microsoft.com/v1.0 when empty
+	OAuth     *oauth2.Config // signs in with the device code flow
+	TokenFile string         // caches the token between runs
+	Client    *http.Client   // for the sign in and graph requests, http.DefaultClient when nil
+
+	decisions []Decision // why each event of the last Meetings call was kept or dropped
+}
+
+const graphURL = "https://graph.microsoft.com/v1.0"
+
+// NewGraphProvider returns the provider of a graph entry of the providers in config.json
+func NewGraphProvider(config *utils.Config, pc utils.ProviderConfig) *GraphProvider {
+	endpoint := microsoft.AzureADEndpoint(pc.Tenant)
+	endpoint.DeviceAuthURL = strings.TrimSuffix(endpoint.TokenURL, "/token") + "/devicecode"
+
+	p := &GraphProvider{
+		Calendar: pc.Name,
+		OAuth: &oauth2.Config{
+			ClientID: pc.ClientID,
+			Endpoint: endpoint,
+			Scopes:   []string{"offline_access", "Calendars.Read"},
+		},
+		TokenFile: pc.TokenFile,
+	}
+	if p.Calendar == "" {
+		p.Calendar = "outlook"
+	}
+	if p.TokenFile == "" {
+		p.TokenFile = "graph-token.json"
+	}
+	return p
+}
+
+// graphEvent is the part of a graph event the provider reads
+type graphEvent struct {
+	ID               string
+	Subject          string
+	IsCancelled      bool
+	IsAllDay         bool
+	OnlineMeetingURL string
+	OnlineMeeting    *struct {
+		JoinURL string `json:"joinUrl"`
+	}
+	Start          graphTime
+	End            graphTime
+	Location       struct{ DisplayName string }
+	BodyPreview    string
+	Organizer      graphRecipient
+	ResponseStatus struct{ Response string }
+	Attendees      []struct {
+		Type         string
+		EmailAddress graphAddress
+	}
+}
+
+type graphTime struct {
+	DateTime string
+	TimeZone string
+}
+
+type graphRecipient struct {
+	EmailAddress graphAddress
+}
+
+type graphAddress struct {
+	Name    string
+	Address string
+}
+
+// graphPage is a page of the calendar view, the next one is at NextLink
+type graphPage struct {
+	Value    []graphEvent
+	NextLink string `json:"@odata.nextLink"`
+}
+
+// Meetings returns the meetings of the calendar view between from and to, to is a day after from when zero
+func (p *GraphProvider) Meetings(ctx context.Context, from, to time.Time) (MeetItems, error) {
+	p.decisions = nil
+	if to.IsZero() {
+		to = from.Add(24 * time.Hour)
+	}
+	if p.Client != nil {
+		ctx = context.WithValue(ctx, oauth2.HTTPClient, p.Client)
+	}
+
+	ts, err := p.tokenSource(ctx)
+	if err != nil {
+		return nil, err
+	}
+	client := oauth2.NewClient(ctx, ts)
+
+	base := p.BaseURL
+	if base == "" {
+		base = graphURL
+	}
+	q := url.Values{}
+	q.Set("startDateTime", from.UTC().Format(time.RFC3339))
+	q.Set("endDateTime", to.UTC().Format(time.RFC3339))
+	q.Set("$orderby", "start/dateTime")
+	q.Set("$top", "50")
+	q.Set("$select", "id,subject,isCancelled,isAllDay,onlineMeeting,onlineMeetingUrl,start,end,location,bodyPreview,organizer,responseStatus,attendees")
+	next := base + "/me/calendarView?" + q.Encode()
+
+	meetings := MeetItems{}
+	for next != "" {
+		page, err := p.page(ctx, client, next)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range page.Value {
+			mi, reason := p.meeting(e)
+			start, _ := e.Start.time()
+			p.decisions = append(p.decisions, Decision{Calendar: p.Calendar, EventID: e.ID, Summary: e.Subject, Start: start, Kept: mi != nil, Reason: reason})
+			if mi != nil {
+				meetings = append(meetings, *mi)
+			}
+		}
+		next = page.NextLink
+	}
+	return meetings, nil
+}
+
+// Decisions returns why each event of the last Meetings call was kept or dropped
+func (p *GraphProvider) Decisions() []Decision {
+	return p.decisions
+}
+
+// page fetches a page of the calendar view with the times in utc
+func (p *GraphProvider) page(ctx context.Context, client *http.Client, target string) (*graphPage, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Prefer", `outlook.timezone="UTC"`)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<10))
+		return nil, fmt.Errorf("graph calendarView: %s: %s", resp.Status, strings.TrimSpace(string(b)))
+	}
+
+	page := &graphPage{}
+	if err := json.NewDecoder(resp.Body).Decode(page); err != nil {
+		return nil, fmt.Errorf("graph calendarView: %w", err)
+	}
+	return page, nil
+}
+
+// meeting maps an event to the MeetItem to join, nil for the ones not to join, and says why
+func (p *GraphProvider) meeting(e graphEvent) (*MeetItem, string) {
+	switch {
+	case e.IsCancelled:
+		return nil, "cancelled"
+	case e.IsAllDay:
+		return nil, "all day"
+	case e.ResponseStatus.Response == "declined":
+		return nil, "declined"
+	}
+
+	// the browser only joins google meet, the teams link of an online meeting is only kept to say why it
+	// is dropped
+	uri := conferenceLink(e.Location.DisplayName, e.BodyPreview)
+	if !joinable(uri) && e.OnlineMeeting != nil && e.OnlineMeeting.JoinURL != "" {
+		uri = e.OnlineMeeting.JoinURL
+	} else if !joinable(uri) && e.OnlineMeetingURL != "" {
+		uri = e.OnlineMeetingURL
+	}
+	switch {
+	case uri == "":
+		return nil, "no conference link"
+	case !joinable(uri):
+		return nil, unsupported(uri)
+	}
+
+	start, err := e.Start.time()
+	if err != nil {
+		log.Warnf("Skipping graph event %s: %v", e.ID, err)
+		return nil, "bad start: " + err.Error()
+	}
+	end, err := e.End.time()
+	if err != nil {
+		log.Warnf("Skipping graph event %s: %v", e.ID, err)
+		return nil, "bad end: " + err.Error()
+	}
+
+	mi := &MeetItem{
+		Uri:       uri,
+		Summary:   e.Subject,
+		StartTime: start,
+		EndTime:   end,
+		Calendar:  p.Calendar,
+		Organizer: e.Organizer.EmailAddress.Address,
+		Response:  graphResponse(e.ResponseStatus.Response),
+	}
+	for _, a := range e.Attendees {
+		if a.Type != "resource" {
+			continue
+		}
+		name := a.EmailAddress.Name
+		if name == "" {
+			name = a.EmailAddress.Address
+		}
+		mi.Rooms = append(mi.Rooms, name)
+	}
+	if mi.Response == "organizer" {
+		return mi, "organizer"
+	}
+	return mi, "invited"
+}
+
+// graphResponse maps the graph response status to the one of MeetItem
+func graphResponse(response string) string {
+	switch response {
+	case "organizer", "accepted":
+		return response
+	case "tentativelyAccepted":
+		return "tentative"
+	}
+	return "none"
+}
+
+// time parses a graph date time, they have no offset and up to 7 fractional digits
+func (gt graphTime) time() (time.Time, error) {
+	loc := time.UTC
+	if gt.TimeZone != "" && gt.TimeZone != "UTC" {
+		l, err := time.LoadLocation(gt.TimeZone)
+		if err != nil {
+			return time.Time{}, err
+		}
+		loc = l
+	}
+	return time.ParseInLocation("2006-01-02T15:04:05.9999999", gt.DateTime, loc)
+}
+
+// tokenSource returns the cached token, refreshed when it expires, and signs in when there is none
+func (p *GraphProvider) tokenSource(ctx context.Context) (oauth2.TokenSource, error) {
+	tok, err := tokenFromFile(p.TokenFile)
+	if err != nil {
+		tok, err = p.signIn(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if err := writeToken(p.TokenFile, tok); err != nil {
+			return nil, err
+		}
+	}
+	return &cachedTokenSource{src: p.OAuth.TokenSource(ctx, tok), file: p.TokenFile, last: tok.AccessToken}, nil
+}
+
+// signIn runs the device code flow, it waits for the user to enter the code
+func (p *GraphProvider) signIn(ctx context.Context) (*oauth2.Token, error) {
+	da, err := p.OAuth.DeviceAuth(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("graph sign in: %w", err)
+	}
+	fmt.Printf("To read the %s calendar go to %s and enter the code %s\n", p.Calendar, da.VerificationURI, da.UserCode)
+
+	tok, err := p.OAuth.DeviceAccessToken(ctx, da)
+	if err != nil {
+		return nil, fmt.Errorf("graph sign in: %w", err)
+	}
+	return tok, nil
+}
+
+// cachedTokenSource writes the token to the file whenever it is refreshed
+type cachedTokenSource struct {
+	src  oauth2.TokenSource
+	file string
+	last string
+}
+
+func (c *cachedTokenSource) Token() (*oauth2.Token, error) {
+	tok, err := c.src.Token()
+	if err != nil {
+		return nil, err
+	}
+	if tok.AccessToken != c.last {
+		if err := writeToken(c.file, tok); err != nil {
+			log.Errorf("Unable to cache graph token: %v", err)
+		}
+		c.last = tok.AccessToken
+	}
+	return tok, nil
+}
+
+// writeToken saves the token readable only by the user
+func writeToken(path string, tok *oauth2.Token) error {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return json.NewEncoder(f).Encode(tok)
+}
@@ -0,0 +1,173 @@
+package calendar
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// graphServer is an in-process stand-in for the azure ad token endpoints and the graph calendar view
+type graphServer struct {
+	t      *testing.T
+	tokens []string // the grant types asked for
+}
+
+func (s *graphServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/common/oauth2/v2.0/devicecode":
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"device_code":"dc-1","user_code":"ABCD-EFGH","verification_uri":"https://microsoft.com/devicelogin","expires_in":900,"interval":1}`)
+	case "/common/oauth2/v2.0/token":
+		r.ParseForm()
+		grant := r.PostForm.Get("grant_type")
+		s.tokens = append(s.tokens, grant)
+		access := map[string]string{
+			"urn:ietf:params:oauth:grant-type:device_code": "at-1",
+			"refresh_token": "at-2",
+		}[grant]
+		if access == "" {
+			http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"access_token":%q,"refresh_token":"rt-1","token_type":"Bearer","expires_in":3600}`, access)
+	case "/v1.0/me/calendarView":
+		if auth := r.Header.Get("Authorization"); auth != "Bearer at-1" && auth != "Bearer at-2" {
+			http.Error(w, `{"error":{"code":"InvalidAuthenticationToken"}}`, http.StatusUnauthorized)
+			return
+		}
+		if r.Header.Get("Prefer") != `outlook.timezone="UTC"` {
+			s.t.Errorf("calendarView Prefer = %q, want the times in utc", r.Header.Get("Prefer"))
+		}
+		if got := r.URL.Query().Get("startDateTime") + "/" + r.URL.Query().Get("endDateTime"); r.URL.Query().Get("page") == "" && got != "2024-03-05T00:00:00Z/2024-03-06T00:00:00Z" {
+			s.t.Errorf("calendarView window = %s, want the day asked for", got)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("page") == "2" {
+			fmt.Fprint(w, `{"value":[
+				{"id":"4","subject":"Vendor check-in","start":{"dateTime":"2024-03-05T17:00:00.0000000","timeZone":"UTC"},"end":{"dateTime":"2024-03-05T17:30:00.0000000","timeZone":"UTC"},
+				 "location":{"displayName":"https://meet.google.com/ven-dorc-hek"},"organizer":{"emailAddress":{"address":"dathan@example.com"}},"responseStatus":{"response":"organizer"}},
+				{"id":"6","subject":"Teams sync","isOnlineMeeting":true,"onlineMeeting":{"joinUrl":"https://teams.microsoft.com/l/meetup-join/19%3ameeting_NjQ5%40thread.v2/0"},
+				 "start":{"dateTime":"2024-03-05T18:00:00.0000000","timeZone":"UTC"},"end":{"dateTime":"2024-03-05T18:30:00.0000000","timeZone":"UTC"},"responseStatus":{"response":"accepted"}},
+				{"id":"5","subject":"Lunch","start":{"dateTime":"2024-03-05T19:00:00.0000000","timeZone":"UTC"},"end":{"dateTime":"2024-03-05T20:00:00.0000000","timeZone":"UTC"},
+				 "location":{"displayName":"Cafeteria"},"responseStatus":{"response":"organizer"}}
+			]}`)
+			return
+		}
+		fmt.Fprintf(w, `{"value":[
+			{"id":"1","subject":"Design review","bodyPreview":"Sam is inviting you to a meeting. Join with Google Meet: https://meet.google.com/abc-defg-hij Join by phone",
+			 "start":{"dateTime":"2024-03-05T15:00:00.0000000","timeZone":"UTC"},"end":{"dateTime":"2024-03-05T16:00:00.0000000","timeZone":"UTC"},
+			 "organizer":{"emailAddress":{"name":"Sam","address":"sam@partner.example"}},"responseStatus":{"response":"tentativelyAccepted"},
+			 "attendees":[{"type":"required","emailAddress":{"address":"dathan@example.com"}},{"type":"resource","emailAddress":{"name":"HQ-2 Boardroom (12)","address":"boardroom@example.com"}}]},
+			{"id":"2","subject":"Declined sync","onlineMeeting":{"joinUrl":"https://teams.microsoft.com/l/meetup-join/2"},
+			 "start":{"dateTime":"2024-03-05T16:00:00.0000000","timeZone":"UTC"},"end":{"dateTime":"2024-03-05T16:30:00.0000000","timeZone":"UTC"},"responseStatus":{"response":"declined"}},
+			{"id":"3","subject":"Cancelled sync","isCancelled":true,"onlineMeeting":{"joinUrl":"https://teams.microsoft.com/l/meetup-join/3"},
+			 "start":{"dateTime":"2024-03-05T16:30:00.0000000","timeZone":"UTC"},"end":{"dateTime":"2024-03-05T17:00:00.0000000","timeZone":"UTC"},"responseStatus":{"response":"accepted"}}
+		],"@odata.nextLink":"%s/v1.0/me/calendarView?page=2"}`, "http://"+r.Host)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func newGraphProvider(t *testing.T, ts *httptest.Server) *GraphProvider {
+	return &GraphProvider{
+		Calendar: "outlook",
+		BaseURL:  ts.URL + "/v1.0",
+		OAuth: &oauth2.Config{
+			ClientID: "app",
+			Endpoint: oauth2.Endpoint{
+				DeviceAuthURL: ts.URL + "/common/oauth2/v2.0/devicecode",
+				TokenURL:      ts.URL + "/common/oauth2/v2.0/token",
+			},
+			Scopes: []string{"offline_access", "Calendars.Read"},
+		},
+		TokenFile: filepath.Join(t.TempDir(), "graph-token.json"),
+		Client:    ts.Client(),
+	}
+}
+
+func TestGraphProvider(t *testing.T) {
+	s := &graphServer{t: t}
+	ts := httptest.NewServer(s)
+	defer ts.Close()
+
+	p := newGraphProvider(t, ts)
+	from := time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC)
+	got, err := p.Meetings(context.Background(), from, from.Add(24*time.Hour))
+	if err != nil {
+		t.Fatalf("Meetings() error = %v", err)
+	}
+
+	// declined, cancelled, teams meetings and meetings without a link are left out, the second page is read too
+	sameMeetings(t, got, []meeting{
+		{"Design review", "2024-03-05T15:00:00Z"},
+		{"Vendor check-in", "2024-03-05T17:00:00Z"},
+	})
+
+	review := got[0]
+	if review.Uri != "https://meet.google.com/abc-defg-hij" {
+		t.Errorf("design review link = %s, want the meet link of the body", review.Uri)
+	}
+	if review.Organizer != "sam@partner.example" || review.Response != "tentative" || review.Calendar != "outlook" {
+		t.Errorf("design review = %+v, want sam's meeting accepted tentatively on outlook", review)
+	}
+	if len(review.Rooms) != 1 || review.Rooms[0] != "HQ-2 Boardroom (12)" {
+		t.Errorf("design review rooms = %v, want the boardroom", review.Rooms)
+	}
+	if vendor := got[1]; vendor.Uri != "https://meet.google.com/ven-dorc-hek" || vendor.Response != "organizer" {
+		t.Errorf("vendor check-in = %+v, want the meet link of the location organized by the user", vendor)
+	}
+
+	ds := p.Decisions()
+	if len(ds) != 6 {
+		t.Errorf("Decisions() has %d events, want the 6 of both pages", len(ds))
+	}
+	hasDecision(t, ds, "Design review", "2024-03-05T15:00:00Z", true, "invited")
+	hasDecision(t, ds, "Declined sync", "2024-03-05T16:00:00Z", false, "declined")
+	hasDecision(t, ds, "Cancelled sync", "2024-03-05T16:30:00Z", false, "cancelled")
+	hasDecision(t, ds, "Vendor check-in", "2024-03-05T17:00:00Z", true, "organizer")
+	hasDecision(t, ds, "Teams sync", "2024-03-05T18:00:00Z", false, "unsupported conference link teams.microsoft.com")
+	hasDecision(t, ds, "Lunch", "2024-03-05T19:00:00Z", false, "no conference link")
+
+	tok, err := tokenFromFile(p.TokenFile)
+	if err != nil || tok.AccessToken != "at-1" || tok.RefreshToken != "rt-1" {
+		t.Fatalf("cached token = %+v, %v want the one of the device code flow", tok, err)
+	}
+
+	// the cached token is used without signing in again, once it expires it is refreshed and cached
+	tok.Expiry = time.Now().Add(-time.Hour)
+	if err := writeToken(p.TokenFile, tok); err != nil {
+		t.Fatalf("writeToken() error = %v", err)
+	}
+	s.tokens = nil
+	if _, err := p.Meetings(context.Background(), from, from.Add(24*time.Hour)); err != nil {
+		t.Fatalf("Meetings() with an expired token error = %v", err)
+	}
+	if len(s.tokens) != 1 || s.tokens[0] != "refresh_token" {
+		t.Errorf("token grants = %v, want a single refresh", s.tokens)
+	}
+	if tok, _ := tokenFromFile(p.TokenFile); tok == nil || tok.AccessToken != "at-2" {
+		t.Errorf("cached token = %+v, want the refreshed one", tok)
+	}
+}
+
+func TestGraphProvider_unauthorized(t *testing.T) {
+	ts := httptest.NewServer(&graphServer{t: t})
+	defer ts.Close()
+
+	p := newGraphProvider(t, ts)
+	if err := writeToken(p.TokenFile, &oauth2.Token{AccessToken: "revoked", TokenType: "Bearer", Expiry: time.Now().Add(time.Hour)}); err != nil {
+		t.Fatalf("writeToken() error = %v", err)
+	}
+
+	if _, err := p.Meetings(context.Background(), time.Now(), time.Time{}); err == nil {
+		t.Errorf("Meetings() with a revoked token should fail")
+	}
+}
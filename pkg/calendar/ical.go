@@ -0,0 +1,642 @@
+package calendar
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/teambition/rrule-go"
+)
+
+// component is a BEGIN:NAME ... END:NAME block of an iCalendar object
+type component struct {
+	name       string
+	props      []property
+	components []*component
+}
+
+// property is a content line, NAME;PARAM=VALUE:value
+type property struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+// get returns the first property with the name, nil when there is none
+func (c *component) get(name string) *property {
+	for i := range c.props {
+		if c.props[i].name == name {
+			return &c.props[i]
+		}
+	}
+	return nil
+}
+
+// text returns the unescaped value of the first property with the name
+func (c *component) text(name string) string {
+	p := c.get(name)
+	if p == nil {
+		return ""
+	}
+	return unescapeText(p.value)
+}
+
+// all returns every property with the name
+func (c *component) all(name string) []property {
+	var ret []property
+	for _, p := range c.props {
+		if p.name == name {
+			ret = append(ret, p)
+		}
+	}
+	return ret
+}
+
+// parseICal reads the components of an iCalendar stream, usually a single VCALENDAR
+func parseICal(r io.Reader) ([]*component, error) {
+	var roots, stack []*component
+
+	handle := func(line string) error {
+		if line == "" {
+			return nil
+		}
+		p, err := parseLine(line)
+		if err != nil {
+			return err
+		}
+
+		switch p.name {
+		case "BEGIN":
+			c := &component{name: strings.ToUpper(p.value)}
+			if len(stack) == 0 {
+				roots = append(roots, c)
+			} else {
+				top := stack[len(stack)-1]
+				top.components = append(top.components, c)
+			}
+			stack = append(stack, c)
+
+		case "END":
+			if len(stack) == 0 || stack[len(stack)-1].name != strings.ToUpper(p.value) {
+				return fmt.Errorf("unexpected END:%s", p.value)
+			}
+			stack = stack[:len(stack)-1]
+
+		default:
+			if len(stack) > 0 {
+				top := stack[len(stack)-1]
+				top.props = append(top.props, p)
+			}
+		}
+		return nil
+	}
+
+	// long lines are folded, a line starting with a space or a tab continues the one before
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1<<20)
+	var line strings.Builder
+	for scanner.Scan() {
+		l := strings.TrimRight(scanner.Text(), "\r")
+		if strings.HasPrefix(l, " ") || strings.HasPrefix(l, "\t") {
+			line.WriteString(l[1:])
+			continue
+		}
+		if err := handle(line.String()); err != nil {
+			return nil, err
+		}
+		line.Reset()
+		line.WriteString(l)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if err := handle(line.String()); err != nil {
+		return nil, err
+	}
+
+	if len(stack) > 0 {
+		return nil, fmt.Errorf("%s is not closed", stack[len(stack)-1].name)
+	}
+	return roots, nil
+}
+
+// parseLine splits a content line, parameter values may be quoted and hold ; and :
+func parseLine(line string) (property, error) {
+	p := property{params: map[string]string{}}
+
+	end := strings.IndexAny(line, ";:")
+	if end < 0 {
+		return p, fmt.Errorf("no value in %q", line)
+	}
+	p.name = strings.ToUpper(line[:end])
+	rest := line[end:]
+
+	for strings.HasPrefix(rest, ";") {
+		rest = rest[1:]
+		eq := strings.IndexByte(rest, '=')
+		if eq < 0 {
+			return p, fmt.Errorf("bad parameter in %q", line)
+		}
+		name := strings.ToUpper(rest[:eq])
+		rest = rest[eq+1:]
+
+		var value string
+		if strings.HasPrefix(rest, `"`) {
+			q := strings.IndexByte(rest[1:], '"')
+			if q < 0 {
+				return p, fmt.Errorf("unterminated quote in %q", line)
+			}
+			value, rest = rest[1:q+1], rest[q+2:]
+		} else {
+			stop := strings.IndexAny(rest, ";:")
+			if stop < 0 {
+				return p, fmt.Errorf("no value in %q", line)
+			}
+			value, rest = rest[:stop], rest[stop:]
+		}
+		p.params[name] = value
+	}
+
+	if !strings.HasPrefix(rest, ":") {
+		return p, fmt.Errorf("no value in %q", line)
+	}
+	p.value = rest[1:]
+	return p, nil
+}
+
+var textEscapes = strings.NewReplacer(`\n`, "\n", `\N`, "\n", `\,`, ",", `\;`, ";", `\\`, `\`)
+
+func unescapeText(s string) string {
+	return textEscapes.Replace(s)
+}
+
+// zone turns the wall clock of an event into instants and back, wall clocks are carried in UTC
+type zone interface {
+	at(wall time.Time) time.Time
+	wall(t time.Time) time.Time
+}
+
+// locationZone is a zone of the tz database, or UTC and floating times in the local zone
+type locationZone struct {
+	loc *time.Location
+}
+
+func (z locationZone) at(wall time.Time) time.Time {
+	return time.Date(wall.Year(), wall.Month(), wall.Day(), wall.Hour(), wall.Minute(), wall.Second(), 0, z.loc)
+}
+
+func (z locationZone) wall(t time.Time) time.Time {
+	t = t.In(z.loc)
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.UTC)
+}
+
+// vtimezone is a zone defined in the file, for names like "Pacific Standard Time" the tz database does not know
+type vtimezone struct {
+	tzid        string
+	observances []observance
+}
+
+// observance is a STANDARD or DAYLIGHT block, the offset applies from each onset until the next one
+type observance struct {
+	start      time.Time
+	rule       *rrule.RRule
+	offsetTo   int
+	offsetFrom int
+}
+
+func newVTimezone(c *component) (*vtimezone, error) {
+	z := &vtimezone{tzid: c.text("TZID")}
+	for _, sub := range c.components {
+		if sub.name != "STANDARD" && sub.name != "DAYLIGHT" {
+			continue
+		}
+
+		var o observance
+		var err error
+		if p := sub.get("DTSTART"); p != nil {
+			if o.start, err = time.Parse("20060102T150405", p.value); err != nil {
+				return nil, fmt.Errorf("%s: %w", z.tzid, err)
+			}
+		}
+		if o.offsetTo, err = parseOffset(sub.text("TZOFFSETTO")); err != nil {
+			return nil, fmt.Errorf("%s: %w", z.tzid, err)
+		}
+		if o.offsetFrom, err = parseOffset(sub.text("TZOFFSETFROM")); err != nil {
+			return nil, fmt.Errorf("%s: %w", z.tzid, err)
+		}
+		if p := sub.get("RRULE"); p != nil {
+			opt, err := rrule.StrToROptionInLocation(p.value, time.UTC)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", z.tzid, err)
+			}
+			// rrule stops a few hundred years after the start and outlook starts its zones in 1601,
+			// a yearly onset does not depend on the year it is counted from
+			if opt.Freq == rrule.YEARLY && opt.Interval <= 1 && o.start.Year() < 1970 {
+				o.start = o.start.AddDate(1970-o.start.Year(), 0, 0)
+			}
+			opt.Dtstart = o.start
+			if o.rule, err = rrule.NewRRule(*opt); err != nil {
+				return nil, fmt.Errorf("%s: %w", z.tzid, err)
+			}
+		}
+		z.observances = append(z.observances, o)
+	}
+
+	if len(z.observances) == 0 {
+		return nil, fmt.Errorf("%s has no STANDARD or DAYLIGHT", z.tzid)
+	}
+	return z, nil
+}
+
+// offset returns the offset in effect at the wall clock, the one before the first onset when it is earlier
+func (z *vtimezone) offset(wall time.Time) int {
+	var latest time.Time
+	off, found := 0, false
+	for _, o := range z.observances {
+		onset := time.Time{}
+		if o.rule != nil {
+			onset = o.rule.Before(wall, true)
+		} else if !o.start.After(wall) {
+			onset = o.start
+		}
+		if !onset.IsZero() && (!found || onset.After(latest)) {
+			latest, off, found = onset, o.offsetTo, true
+		}
+	}
+	if found {
+		return off
+	}
+
+	first := z.observances[0]
+	for _, o := range z.observances[1:] {
+		if o.start.Before(first.start) {
+			first = o
+		}
+	}
+	return first.offsetFrom
+}
+
+func (z *vtimezone) at(wall time.Time) time.Time {
+	return time.Date(wall.Year(), wall.Month(), wall.Day(), wall.Hour(), wall.Minute(), wall.Second(), 0, time.FixedZone(z.tzid, z.offset(wall)))
+}
+
+func (z *vtimezone) wall(t time.Time) time.Time {
+	guess := t.UTC().Add(time.Duration(z.offset(t.UTC())) * time.Second)
+	return t.UTC().Add(time.Duration(z.offset(guess)) * time.Second)
+}
+
+// parseOffset reads a utc offset like -0800 or +053000 into seconds
+func parseOffset(s string) (int, error) {
+	if len(s) != 5 && len(s) != 7 || (s[0] != '+' && s[0] != '-') {
+		return 0, fmt.Errorf("bad utc offset %q", s)
+	}
+	n, err := strconv.Atoi(s[1:])
+	if err != nil {
+		return 0, fmt.Errorf("bad utc offset %q", s)
+	}
+	if len(s) == 5 {
+		n *= 100
+	}
+	secs := n/10000*3600 + n/100%100*60 + n%100
+	if s[0] == '-' {
+		secs = -secs
+	}
+	return secs, nil
+}
+
+// zones resolves the TZID of a date, the tz database first and the VTIMEZONEs of the file after that
+type zones map[string]*vtimezone
+
+func (zs zones) get(tzid string) zone {
+	if tzid == "" {
+		return locationZone{time.Local} // a floating time
+	}
+	if loc, err := time.LoadLocation(tzid); err == nil {
+		return locationZone{loc}
+	}
+	if z, ok := zs[tzid]; ok {
+		return z
+	}
+	return locationZone{time.Local}
+}
+
+// dates reads the value of a date property, a list for EXDATE and RDATE.
+// It returns the wall clocks, their zone and whether they are dates without a time.
+func (zs zones) dates(p property) ([]time.Time, zone, bool, error) {
+	z := zs.get(p.params["TZID"])
+	allDay := p.params["VALUE"] == "DATE"
+
+	var ret []time.Time
+	for _, v := range strings.Split(p.value, ",") {
+		var t time.Time
+		var err error
+		switch {
+		case len(v) == len("20060102"):
+			t, err = time.Parse("20060102", v)
+			allDay = true
+		case strings.HasSuffix(v, "Z"):
+			t, err = time.Parse("20060102T150405Z", v)
+			z = locationZone{time.UTC}
+		default:
+			t, err = time.Parse("20060102T150405", v)
+		}
+		if err != nil {
+			return nil, nil, false, fmt.Errorf("%s: %w", p.name, err)
+		}
+		ret = append(ret, t)
+	}
+	return ret, z, allDay, nil
+}
+
+// instants reads a date property into points in time
+func (zs zones) instants(p property) ([]time.Time, error) {
+	walls, z, _, err := zs.dates(p)
+	if err != nil {
+		return nil, err
+	}
+	for i, w := range walls {
+		walls[i] = z.at(w)
+	}
+	return walls, nil
+}
+
+var isoDuration = regexp.MustCompile(`^([+-])?P(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+// parseDuration reads a DURATION like PT1H30M or P1D
+func parseDuration(s string) (time.Duration, error) {
+	m := isoDuration.FindStringSubmatch(s)
+	if m == nil || s == "P" || s == "PT" {
+		return 0, fmt.Errorf("bad duration %q", s)
+	}
+
+	var d time.Duration
+	for i, unit := range []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second} {
+		if m[i+2] != "" {
+			n, _ := strconv.Atoi(m[i+2])
+			d += time.Duration(n) * unit
+		}
+	}
+	if m[1] == "-" {
+		d = -d
+	}
+	return d, nil
+}
+
+// vevent is what is needed of a VEVENT to join it
+type vevent struct {
+	uid      string
+	summary  string
+	link     string
+	rooms    []string
+	status   string // cancelled or declined, every occurrence is dropped
+	allDay   bool
+	start    time.Time // wall clock in zone
+	zone     zone
+	duration time.Duration
+	rule     *rrule.ROption
+	rdates   []time.Time
+	exdates  map[int64]bool
+	moves    time.Time // the instant of the occurrence this event replaces, zero for the series itself
+}
+
+var untilUTC = regexp.MustCompile(`UNTIL=\d{8}T\d{6}Z`)
+
+// newVEvent reads the event, email is the attendee whose declines are left out
+func newVEvent(c *component, zs zones, email string) (*vevent, error) {
+	ev := &vevent{
+		uid:     c.text("UID"),
+		summary: c.text("SUMMARY"),
+		exdates: map[int64]bool{},
+		link: conferenceLink(
+			c.text("X-GOOGLE-CONFERENCE"),
+			c.text("X-MICROSOFT-SKYPETEAMSMEETINGURL"),
+			c.text("URL"),
+			c.text("LOCATION"),
+			c.text("DESCRIPTION"),
+		),
+	}
+	if strings.EqualFold(c.text("STATUS"), "CANCELLED") {
+		ev.status = "cancelled"
+	}
+
+	start := c.get("DTSTART")
+	if start == nil {
+		return nil, fmt.Errorf("event %s has no DTSTART", ev.uid)
+	}
+	walls, z, allDay, err := zs.dates(*start)
+	if err != nil {
+		return nil, err
+	}
+	ev.start, ev.zone, ev.allDay = walls[0], z, allDay
+
+	if end := c.get("DTEND"); end != nil {
+		ends, ez, _, err := zs.dates(*end)
+		if err != nil {
+			return nil, err
+		}
+		ev.duration = ez.at(ends[0]).Sub(ev.zone.at(ev.start))
+	} else if d := c.get("DURATION"); d != nil {
+		if ev.duration, err = parseDuration(d.value); err != nil {
+			return nil, err
+		}
+	} else if ev.allDay {
+		ev.duration = 24 * time.Hour
+	}
+
+	if p := c.get("RRULE"); p != nil {
+		ev.rule, err = rrule.StrToROptionInLocation(p.value, time.UTC)
+		if err != nil {
+			return nil, fmt.Errorf("event %s: %w", ev.uid, err)
+		}
+		// an UNTIL in utc is an instant, the rule is expanded on the wall clock
+		if untilUTC.MatchString(p.value) {
+			ev.rule.Until = ev.zone.wall(ev.rule.Until)
+		}
+	}
+	for _, p := range c.all("RDATE") {
+		ts, err := zs.instants(p)
+		if err != nil {
+			return nil, err
+		}
+		ev.rdates = append(ev.rdates, ts...)
+	}
+	for _, p := range c.all("EXDATE") {
+		ts, err := zs.instants(p)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range ts {
+			ev.exdates[t.Unix()] = true
+		}
+	}
+	if p := c.get("RECURRENCE-ID"); p != nil {
+		ts, err := zs.instants(*p)
+		if err != nil {
+			return nil, err
+		}
+		ev.moves = ts[0]
+	}
+
+	for _, a := range c.all("ATTENDEE") {
+		addr := strings.TrimPrefix(strings.ToLower(a.value), "mailto:")
+		if email != "" && addr == strings.ToLower(email) && strings.EqualFold(a.params["PARTSTAT"], "DECLINED") {
+			ev.status = "declined"
+		}
+		if cu := strings.ToUpper(a.params["CUTYPE"]); cu == "ROOM" || cu == "RESOURCE" {
+			name := a.params["CN"]
+			if name == "" {
+				name = addr
+			}
+			ev.rooms = append(ev.rooms, name)
+		}
+	}
+
+	return ev, nil
+}
+
+// occurrences returns the starts of the event that may fall between from and to, a day either side is kept
+// so the caller can filter on the exact instants
+func (ev *vevent) occurrences(from, to time.Time) ([]time.Time, error) {
+	var ret []time.Time
+	if ev.rule == nil {
+		ret = append(ret, ev.zone.at(ev.start))
+	} else {
+		opt := *ev.rule
+		opt.Dtstart = ev.start
+		r, err := rrule.NewRRule(opt)
+		if err != nil {
+			return nil, fmt.Errorf("event %s: %w", ev.uid, err)
+		}
+		after := ev.zone.wall(from).Add(-ev.duration - 24*time.Hour)
+		before := ev.zone.wall(to).Add(24 * time.Hour)
+		for _, w := range r.Between(after, before, true) {
+			ret = append(ret, ev.zone.at(w))
+		}
+	}
+	ret = append(ret, ev.rdates...)
+
+	seen := map[int64]bool{}
+	out := ret[:0]
+	for _, t := range ret {
+		if ev.exdates[t.Unix()] || seen[t.Unix()] {
+			continue
+		}
+		seen[t.Unix()] = true
+		out = append(out, t)
+	}
+	return out, nil
+}
+
+// dropped is why the occurrences of the event are not joined, empty when they are
+func (ev *vevent) dropped() string {
+	switch {
+	case ev.status != "":
+		return ev.status
+	case ev.allDay:
+		return "all day"
+	case ev.link == "":
+		return "no conference link"
+	case !joinable(ev.link):
+		return unsupported(ev.link)
+	}
+	return ""
+}
+
+// readICal returns the meetings with a conference link in the iCalendar stream that are not over by from
+// and start before to and why each event was kept or dropped. Recurring events are expanded, email is the
+// attendee whose declines are left out. An event or time zone that cannot be read is left out, not the feed.
+func readICal(r io.Reader, calendar, email string, from, to time.Time) (MeetItems, []Decision, error) {
+	roots, err := parseICal(r)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	zs := zones{}
+	var raw []*component
+	for _, cal := range roots {
+		for _, c := range cal.components {
+			switch c.name {
+			case "VTIMEZONE":
+				z, err := newVTimezone(c)
+				if err != nil {
+					log.Warnf("Skipping time zone %s of %s: %v", c.text("TZID"), calendar, err)
+					continue
+				}
+				zs[z.tzid] = z
+			case "VEVENT":
+				raw = append(raw, c)
+			}
+		}
+	}
+
+	var events []*vevent
+	var decisions []Decision
+	moved := map[string]map[int64]bool{} // the occurrences replaced by another event, by uid
+	for _, c := range raw {
+		ev, err := newVEvent(c, zs, email)
+		if err != nil {
+			decisions = append(decisions, unreadable(calendar, c.text("UID"), c.text("SUMMARY"), err))
+			continue
+		}
+		events = append(events, ev)
+		if !ev.moves.IsZero() {
+			if moved[ev.uid] == nil {
+				moved[ev.uid] = map[int64]bool{}
+			}
+			moved[ev.uid][ev.moves.Unix()] = true
+		}
+	}
+
+	meetings := MeetItems{}
+	for _, ev := range events {
+		starts, err := ev.occurrences(from, to)
+		if err != nil {
+			decisions = append(decisions, unreadable(calendar, ev.uid, ev.summary, err))
+			continue
+		}
+
+		in := 0
+		for _, st := range starts {
+			if ev.moves.IsZero() && moved[ev.uid][st.Unix()] {
+				continue
+			}
+			end := st.Add(ev.duration)
+			if !end.After(from) && st.Before(from) || !st.Before(to) {
+				continue
+			}
+			in++
+
+			d := Decision{Calendar: calendar, EventID: ev.uid, Summary: ev.summary, Start: st, Reason: ev.dropped()}
+			if d.Reason == "" {
+				d.Kept, d.Reason = true, "on the calendar"
+				meetings = append(meetings, MeetItem{
+					Uri:       ev.link,
+					Summary:   ev.summary,
+					StartTime: st,
+					EndTime:   end,
+					Calendar:  calendar,
+					Rooms:     ev.rooms,
+				})
+			}
+			decisions = append(decisions, d)
+		}
+		if in == 0 {
+			decisions = append(decisions, Decision{Calendar: calendar, EventID: ev.uid, Summary: ev.summary, Start: ev.zone.at(ev.start), Reason: "out of window"})
+		}
+	}
+
+	sort.SliceStable(meetings, func(i, j int) bool { return meetings[i].StartTime.Before(meetings[j].StartTime) })
+	return meetings, decisions, nil
+}
+
+// unreadable logs the event that cannot be read and returns the decision dropping it
+func unreadable(calendar, uid, summary string, err error) Decision {
+	log.Warnf("Skipping event %s of %s: %v", uid, calendar, err)
+	return Decision{Calendar: calendar, EventID: uid, Summary: summary, Reason: "unreadable: " + err.Error()}
+}
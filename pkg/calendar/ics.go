@@ -0,0 +1,81 @@
+package calendar
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/dathan/go-grpc-video-call-manager/internal/utils"
+)
+
+// ICSProvider reads the meetings of an iCalendar feed, a local .ics file or an http(s) url
+type ICSProvider struct {
+	URL      string
+	Calendar string       // what routes call the calendar
+	Email    string       // meetings this attendee declined are left out
+	Client   *http.Client // http.DefaultClient when nil
+
+	decisions []Decision // why each event of the last Meetings call was kept or dropped
+}
+
+// NewICSProvider returns the provider of an ics entry of the providers in config.json
+func NewICSProvider(config *utils.Config, pc utils.ProviderConfig) *ICSProvider {
+	p := &ICSProvider{URL: pc.URL, Calendar: pc.Name, Email: config.Email}
+	if p.Calendar == "" {
+		p.Calendar = pc.URL
+	}
+	return p
+}
+
+// Meetings returns the meetings of the feed that are not over by from and start before to
+func (p *ICSProvider) Meetings(ctx context.Context, from, to time.Time) (MeetItems, error) {
+	p.decisions = nil
+	body, err := p.open(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	meetings, decisions, err := readICal(body, p.Calendar, p.Email, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", p.URL, err)
+	}
+	p.decisions = decisions
+	return meetings, nil
+}
+
+// Decisions returns why each event of the last Meetings call was kept or dropped
+func (p *ICSProvider) Decisions() []Decision {
+	return p.decisions
+}
+
+// open fetches the url or opens the file
+func (p *ICSProvider) open(ctx context.Context) (io.ReadCloser, error) {
+	if !strings.HasPrefix(p.URL, "http://") && !strings.HasPrefix(p.URL, "https://") {
+		return os.Open(strings.TrimPrefix(p.URL, "file://"))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "text/calendar")
+
+	client := p.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("%s: %s", p.URL, resp.Status)
+	}
+	return resp.Body, nil
+}
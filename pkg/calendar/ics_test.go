@@ -0,0 +1,204 @@
+package calendar
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// meeting is what the tests compare of a MeetItem
+type meeting struct {
+	summary string
+	start   string // RFC3339 in utc
+}
+
+func summarize(items MeetItems) []meeting {
+	var ret []meeting
+	for _, mi := range items {
+		ret = append(ret, meeting{mi.Summary, mi.StartTime.UTC().Format(time.RFC3339)})
+	}
+	return ret
+}
+
+func sameMeetings(t *testing.T, got MeetItems, want []meeting) {
+	t.Helper()
+	g := summarize(got)
+	if len(g) != len(want) {
+		t.Fatalf("got %d meetings %v, want %d %v", len(g), g, len(want), want)
+	}
+	for i := range g {
+		if g[i] != want[i] {
+			t.Errorf("meeting %d = %v, want %v", i, g[i], want[i])
+		}
+	}
+}
+
+// hasDecision fails the test when no decision on the event starting at start, RFC3339 in utc, has the reason
+func hasDecision(t *testing.T, ds []Decision, summary, start string, kept bool, reason string) {
+	t.Helper()
+	for _, d := range ds {
+		if d.Summary == summary && d.Start.UTC().Format(time.RFC3339) == start {
+			if d.Kept != kept || d.Reason != reason {
+				t.Errorf("decision on %s at %s = %q kept %t, want %q kept %t", summary, start, d.Reason, d.Kept, reason, kept)
+			}
+			return
+		}
+	}
+	t.Errorf("no decision on %s at %s, want %q", summary, start, reason)
+}
+
+func TestICSProvider_file(t *testing.T) {
+	p := &ICSProvider{URL: filepath.Join("testdata", "google.ics"), Calendar: "team", Email: "Dathan@example.com"}
+	from := time.Date(2024, 3, 4, 5, 0, 0, 0, time.UTC)
+
+	got, err := p.Meetings(context.Background(), from, from.Add(9*24*time.Hour))
+	if err != nil {
+		t.Fatalf("Meetings() error = %v", err)
+	}
+
+	// the standup stays at 9:30 in new york across the switch to daylight saving time on the 10th,
+	// the 6th is an exception, the 7th was moved and the 11th cancelled. The partner sync is on zoom,
+	// the browser only joins google meet.
+	sameMeetings(t, got, []meeting{
+		{"Standup", "2024-03-04T14:30:00Z"},
+		{"Standup", "2024-03-05T14:30:00Z"},
+		{"Standup (moved)", "2024-03-07T16:00:00Z"},
+		{"Standup", "2024-03-08T14:30:00Z"},
+		{"Standup", "2024-03-12T13:30:00Z"},
+	})
+
+	if got[0].Uri != "https://meet.google.com/frt-ywwd-epk" || got[0].Calendar != "team" {
+		t.Errorf("standup = %+v, want the meet link on the team calendar", got[0])
+	}
+	if got[0].EndTime.Sub(got[0].StartTime) != 15*time.Minute {
+		t.Errorf("standup lasts %s, want 15m", got[0].EndTime.Sub(got[0].StartTime))
+	}
+
+	ds := p.Decisions()
+	hasDecision(t, ds, "Partner sync, weekly", "2024-03-06T18:00:00Z", false, "unsupported conference link acme.zoom.us")
+	hasDecision(t, ds, "Standup", "2024-03-04T14:30:00Z", true, "on the calendar")
+	hasDecision(t, ds, "Standup", "2024-03-11T13:30:00Z", false, "cancelled")
+	hasDecision(t, ds, "Lunch", "2024-03-05T17:00:00Z", false, "no conference link")
+	hasDecision(t, ds, "Declined review", "2024-03-05T20:00:00Z", false, "declined")
+	hasDecision(t, ds, "Offsite", time.Date(2024, 3, 5, 0, 0, 0, 0, time.Local).UTC().Format(time.RFC3339), false, "all day")
+
+	// a meeting that started is kept until it is over
+	got, err = p.Meetings(context.Background(), time.Date(2024, 3, 5, 14, 40, 0, 0, time.UTC), time.Date(2024, 3, 5, 15, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("Meetings() error = %v", err)
+	}
+	sameMeetings(t, got, []meeting{{"Standup", "2024-03-05T14:30:00Z"}})
+	hasDecision(t, p.Decisions(), "Lunch", "2024-03-05T17:00:00Z", false, "out of window")
+}
+
+func TestICSProvider_url(t *testing.T) {
+	outlook, err := os.ReadFile(filepath.Join("testdata", "outlook.ics"))
+	if err != nil {
+		t.Fatalf("os.ReadFile() error = %v", err)
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/owa/calendar.ics" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/calendar")
+		w.Write(outlook)
+	}))
+	defer ts.Close()
+
+	p := &ICSProvider{URL: ts.URL + "/owa/calendar.ics", Calendar: "outlook"}
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	got, err := p.Meetings(context.Background(), from, from.Add(30*24*time.Hour))
+	if err != nil {
+		t.Fatalf("Meetings() error = %v", err)
+	}
+
+	// "Pacific Standard Time" is only known from the VTIMEZONE, daylight saving time starts on the 10th.
+	// The vendor check-in is on teams, the browser only joins google meet.
+	sameMeetings(t, got, []meeting{
+		{"Design review", "2024-03-05T18:00:00Z"},
+		{"Design review", "2024-03-12T17:00:00Z"},
+		{"Design review", "2024-03-19T17:00:00Z"},
+	})
+	for _, start := range []string{"2024-03-05T17:00:00Z", "2024-03-06T17:00:00Z", "2024-03-07T17:00:00Z"} {
+		hasDecision(t, p.Decisions(), "Vendor check-in", start, false, "unsupported conference link teams.microsoft.com")
+	}
+
+	review := got[0]
+	if review.Uri != "https://meet.google.com/abc-defg-hij" {
+		t.Errorf("design review link = %s, want the meet link from the folded description", review.Uri)
+	}
+	if len(review.Rooms) != 1 || review.Rooms[0] != "HQ-2 Boardroom (12)" {
+		t.Errorf("design review rooms = %v, want the boardroom", review.Rooms)
+	}
+
+	p.URL = ts.URL + "/missing.ics"
+	if _, err := p.Meetings(context.Background(), from, from.Add(time.Hour)); err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("Meetings() of a missing feed error = %v, want the 404", err)
+	}
+}
+
+func TestICSProvider_unreadableEvents(t *testing.T) {
+	p := &ICSProvider{URL: filepath.Join("testdata", "partner.ics"), Calendar: "partner"}
+	from := time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC)
+
+	got, err := p.Meetings(context.Background(), from, from.Add(2*24*time.Hour))
+	if err != nil {
+		t.Fatalf("Meetings() error = %v, the events that cannot be read should be left out", err)
+	}
+	sameMeetings(t, got, []meeting{
+		{"Kickoff", "2024-03-05T15:00:00Z"},
+		{"Retro", "2024-03-06T15:00:00Z"},
+	})
+
+	unreadable := map[string]bool{}
+	for _, d := range p.Decisions() {
+		if strings.HasPrefix(d.Reason, "unreadable: ") && !d.Kept {
+			unreadable[d.Summary] = true
+		}
+	}
+	for _, summary := range []string{"Typo in the start", "Office hours", "Biweekly sync"} {
+		if !unreadable[summary] {
+			t.Errorf("%s is not dropped as unreadable, decisions = %+v", summary, p.Decisions())
+		}
+	}
+}
+
+func Test_conferenceLink(t *testing.T) {
+	tests := []struct {
+		texts []string
+		want  string
+	}{
+		{[]string{"", "Join: https://meet.google.com/frt-ywwd-epk\nor dial in"}, "https://meet.google.com/frt-ywwd-epk"},
+		{[]string{"Room 4", "(https://us02web.zoom.us/j/8123456789?pwd=xyz)."}, "https://us02web.zoom.us/j/8123456789?pwd=xyz"},
+		{[]string{"https://acme.webex.com/meet/dathan"}, "https://acme.webex.com/meet/dathan"},
+		{[]string{"https://meet.google.com/aaa-bbbb-ccc", "https://zoom.us/j/1"}, "https://meet.google.com/aaa-bbbb-ccc"},
+		{[]string{"https://zoom.us/j/1", "or https://teams.microsoft.com/l/meetup-join/2 or https://meet.google.com/aaa-bbbb-ccc"}, "https://meet.google.com/aaa-bbbb-ccc"},
+		{[]string{"https://example.com/agenda"}, ""},
+	}
+	for _, tt := range tests {
+		if got := conferenceLink(tt.texts...); got != tt.want {
+			t.Errorf("conferenceLink(%q) = %q, want %q", tt.texts, got, tt.want)
+		}
+	}
+}
+
+func Test_parseLine(t *testing.T) {
+	p, err := parseLine(`ATTENDEE;CUTYPE=ROOM;CN="Boardroom; 2:nd floor":mailto:room@example.com`)
+	if err != nil {
+		t.Fatalf("parseLine() error = %v", err)
+	}
+	if p.name != "ATTENDEE" || p.params["CN"] != "Boardroom; 2:nd floor" || p.params["CUTYPE"] != "ROOM" || p.value != "mailto:room@example.com" {
+		t.Errorf("parseLine() = %+v", p)
+	}
+
+	if _, err := parseLine("SUMMARY"); err == nil {
+		t.Errorf("parseLine() of a line without a value should fail")
+	}
+}
@@ -0,0 +1,103 @@
+package calendar
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/dathan/go-grpc-video-call-manager/internal/utils"
+)
+
+// Provider is a source of meetings
+type Provider interface {
+	// Meetings returns the meetings to join that are not over by from and start before to
+	Meetings(ctx context.Context, from, to time.Time) (MeetItems, error)
+}
+
+// Decision is why an event a provider read was kept or dropped
+type Decision struct {
+	Calendar string
+	EventID  string
+	Summary  string
+	Start    time.Time
+	Kept     bool
+	Reason   string
+}
+
+// Explainer is a provider that tells why it kept or dropped each event of its last Meetings call
+type Explainer interface {
+	Decisions() []Decision
+}
+
+// NewProvider returns the provider the config describes
+func NewProvider(config *utils.Config, pc utils.ProviderConfig) (Provider, error) {
+	switch pc.Type {
+	case "", "google":
+		cs := NewCalService(config)
+		cs.calendars = pc.Calendars
+		cs.subscribed = pc.Subscribed
+		cs.groups = pc.Groups
+		cs.uninvited = pc.JoinUninvited
+		return cs, nil
+	case "ics":
+		if pc.URL == "" {
+			return nil, fmt.Errorf("the ics provider %q needs a url", pc.Name)
+		}
+		return NewICSProvider(config, pc), nil
+	case "caldav":
+		if pc.URL == "" {
+			return nil, fmt.Errorf("the caldav provider %q needs a url", pc.Name)
+		}
+		return NewCalDAVProvider(config, pc)
+	case "graph":
+		if pc.ClientID == "" {
+			return nil, fmt.Errorf("the graph provider %q needs a client_id", pc.Name)
+		}
+		return NewGraphProvider(config, pc), nil
+	}
+	return nil, fmt.Errorf("unknown calendar provider %q", pc.Type)
+}
+
+// Providers returns the providers of the config, the google calendar of credentials.json when none is listed
+func Providers(config *utils.Config) ([]Provider, error) {
+	pcs := config.Providers
+	if len(pcs) == 0 {
+		pcs = []utils.ProviderConfig{{Type: "google"}}
+	}
+
+	ret := make([]Provider, 0, len(pcs))
+	for _, pc := range pcs {
+		p, err := NewProvider(config, pc)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, p)
+	}
+	return ret, nil
+}
+
+// Merge returns the meetings of every list in start order. A meeting on several calendars has
+// the same link and start, only the first one seen is kept.
+func Merge(lists ...MeetItems) MeetItems {
+	type key struct {
+		uri   string
+		start int64
+	}
+
+	seen := map[key]bool{}
+	ret := MeetItems{}
+	for _, items := range lists {
+		for _, mi := range items {
+			k := key{mi.Uri, mi.StartTime.Unix()}
+			if seen[k] {
+				continue
+			}
+			seen[k] = true
+			ret = append(ret, mi)
+		}
+	}
+
+	sort.SliceStable(ret, func(i, j int) bool { return ret[i].StartTime.Before(ret[j].StartTime) })
+	return ret
+}
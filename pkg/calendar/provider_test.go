@@ -0,0 +1,92 @@
+package calendar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dathan/go-grpc-video-call-manager/internal/utils"
+)
+
+func TestMerge(t *testing.T) {
+	at := time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)
+	standup := MeetItem{Uri: "https://meet.google.com/frt-ywwd-epk", Summary: "standup", StartTime: at.Add(time.Hour)}
+	planning := MeetItem{Uri: "https://meet.google.com/abc-defg-hij", Summary: "planning", StartTime: at}
+
+	// the same standup on a second calendar, in another zone, and the one of the next day
+	shared := standup
+	shared.Summary = "standup (team calendar)"
+	shared.StartTime = standup.StartTime.In(time.FixedZone("CET", 3600))
+	tomorrow := standup
+	tomorrow.StartTime = standup.StartTime.Add(24 * time.Hour)
+
+	got := Merge(MeetItems{standup, tomorrow}, nil, MeetItems{shared, planning})
+
+	want := []string{"planning", "standup", "standup"}
+	if len(got) != len(want) {
+		t.Fatalf("Merge() = %+v, want %d meetings", got, len(want))
+	}
+	for i, mi := range got {
+		if mi.Summary != want[i] {
+			t.Errorf("Merge()[%d] = %s, want %s", i, mi.Summary, want[i])
+		}
+	}
+	if !got[2].StartTime.Equal(tomorrow.StartTime) {
+		t.Errorf("Merge() dropped the standup of the next day")
+	}
+}
+
+func TestProviders(t *testing.T) {
+	ps, err := Providers(&utils.Config{})
+	if err != nil || len(ps) != 1 {
+		t.Fatalf("Providers() without any configured = %v, %v want the google calendar", ps, err)
+	}
+	if _, ok := ps[0].(*CalService); !ok {
+		t.Errorf("Providers() default = %T, want *CalService", ps[0])
+	}
+
+	ps, err = Providers(&utils.Config{Providers: []utils.ProviderConfig{{Type: "google", Calendars: []string{"team@group.calendar.google.com"}, Subscribed: true}}})
+	if cs, ok := ps[0].(*CalService); err != nil || !ok || len(cs.calendars) != 1 || !cs.subscribed {
+		t.Errorf("Providers() google = %+v, %v want the calendars of the config", ps[0], err)
+	}
+
+	ps, err = Providers(&utils.Config{Providers: []utils.ProviderConfig{{Type: "google"}, {Type: "ics", URL: "testdata/google.ics"}}})
+	if err != nil || len(ps) != 2 {
+		t.Fatalf("Providers() = %v, %v want google and ics", ps, err)
+	}
+	if ics, ok := ps[1].(*ICSProvider); !ok || ics.Calendar != "testdata/google.ics" {
+		t.Errorf("Providers() ics = %+v, want the feed named after its url", ps[1])
+	}
+
+	if _, err := Providers(&utils.Config{Providers: []utils.ProviderConfig{{Type: "ics"}}}); err == nil {
+		t.Errorf("Providers() with an ics feed without a url should fail")
+	}
+
+	ps, err = Providers(&utils.Config{Providers: []utils.ProviderConfig{{Type: "caldav", URL: "https://cloud.example.com/remote.php/dav/", Username: "dathan", Password: "app-password"}}})
+	if err != nil || len(ps) != 1 {
+		t.Fatalf("Providers() = %v, %v want caldav", ps, err)
+	}
+	if dav, ok := ps[0].(*CalDAVProvider); !ok || dav.Username != "dathan" || dav.Password != "app-password" {
+		t.Errorf("Providers() caldav = %+v, want the credentials of the config", ps[0])
+	}
+
+	if _, err := Providers(&utils.Config{Providers: []utils.ProviderConfig{{Type: "caldav", URL: "https://cloud.example.com/", PasswordFile: "testdata/missing"}}}); err == nil {
+		t.Errorf("Providers() with a missing password file should fail")
+	}
+
+	ps, err = Providers(&utils.Config{Providers: []utils.ProviderConfig{{Type: "graph", ClientID: "app", Tenant: "example.com"}}})
+	if err != nil || len(ps) != 1 {
+		t.Fatalf("Providers() = %v, %v want graph", ps, err)
+	}
+	if graph, ok := ps[0].(*GraphProvider); !ok || graph.Calendar != "outlook" || graph.TokenFile != "graph-token.json" ||
+		graph.OAuth.Endpoint.DeviceAuthURL != "https://login.microsoftonline.com/example.com/oauth2/v2.0/devicecode" {
+		t.Errorf("Providers() graph = %+v, want the defaults and the device code endpoint of the tenant", ps[0])
+	}
+
+	if _, err := Providers(&utils.Config{Providers: []utils.ProviderConfig{{Type: "graph"}}}); err == nil {
+		t.Errorf("Providers() with a graph calendar without a client_id should fail")
+	}
+
+	if _, err := Providers(&utils.Config{Providers: []utils.ProviderConfig{{Type: "outlook"}}}); err == nil {
+		t.Errorf("Providers() with an unknown type should fail")
+	}
+}
@@ -0,0 +1,135 @@
+package calendar
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sort"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/api/calendar/v3"
+	"google.golang.org/api/googleapi"
+)
+
+// eventCache is a calendar as of its last sync. A full sync reads twice the window, the polls after it only
+// ask for the changes with the sync token until the window reaches past what the full sync read.
+type eventCache struct {
+	token  string                     // the nextSyncToken of the last sync, a full sync is next when empty
+	until  time.Time                  // the end of the range the full sync read
+	events map[string]*calendar.Event // by event id
+}
+
+// sync brings the cache of the calendar up to date and returns its events in start order
+func (em *CalService) sync(ctx context.Context, srv *calendar.Service, id string, from, to time.Time) ([]*calendar.Event, error) {
+	if em.caches == nil {
+		em.caches = map[string]*eventCache{}
+	}
+
+	cache := em.caches[id]
+	if cache != nil && cache.token != "" && !to.After(cache.until) {
+		err := cache.incremental(ctx, srv, id)
+		var gerr *googleapi.Error
+		switch {
+		case errors.As(err, &gerr) && gerr.Code == http.StatusGone:
+			log.Infof("The sync token of calendar %s expired, reading it again", id)
+			cache = nil
+		case err != nil:
+			return nil, err
+		}
+	} else {
+		cache = nil
+	}
+
+	if cache == nil {
+		var err error
+		cache, err = fullSync(ctx, srv, id, from, from.Add(2*to.Sub(from)))
+		if err != nil {
+			delete(em.caches, id)
+			return nil, err
+		}
+		em.caches[id] = cache
+	}
+
+	ret := make([]*calendar.Event, 0, len(cache.events))
+	for eid, item := range cache.events {
+		if end := eventTime(item.End); !end.IsZero() && end.Before(from) {
+			delete(cache.events, eid) // over, it cannot change anymore
+			continue
+		}
+		ret = append(ret, item)
+	}
+	sort.SliceStable(ret, func(i, j int) bool {
+		si, sj := eventTime(ret[i].Start), eventTime(ret[j].Start)
+		if si.Equal(sj) {
+			return ret[i].Id < ret[j].Id
+		}
+		return si.Before(sj)
+	})
+	return ret, nil
+}
+
+// fullSync reads the events between from and until and the token for the changes after that
+func fullSync(ctx context.Context, srv *calendar.Service, id string, from, until time.Time) (*eventCache, error) {
+	cache := &eventCache{until: until, events: map[string]*calendar.Event{}}
+
+	call := srv.Events.List(id).SingleEvents(true).
+		TimeMin(from.Format(time.RFC3339)).TimeMax(until.Format(time.RFC3339)).MaxResults(250)
+	err := call.Pages(ctx, func(events *calendar.Events) error {
+		for _, item := range events.Items {
+			if item.Status != "cancelled" {
+				cache.events[item.Id] = item
+			}
+		}
+		cache.token = events.NextSyncToken
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Debugf("Full sync of calendar %s read %d events up to %s", id, len(cache.events), until.Format(time.RFC3339))
+	return cache, nil
+}
+
+// incremental applies the changes since the last sync, cancelled and deleted events are removed
+func (cache *eventCache) incremental(ctx context.Context, srv *calendar.Service, id string) error {
+	changes := 0
+	token := ""
+
+	call := srv.Events.List(id).SingleEvents(true).ShowDeleted(true).SyncToken(cache.token).MaxResults(250)
+	err := call.Pages(ctx, func(events *calendar.Events) error {
+		for _, item := range events.Items {
+			changes++
+			if item.Status == "cancelled" {
+				delete(cache.events, item.Id)
+				continue
+			}
+			cache.events[item.Id] = item
+		}
+		token = events.NextSyncToken
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if changes > 0 {
+		log.Debugf("Calendar %s has %d changes since the last sync", id, changes)
+	}
+	cache.token = token
+	return nil
+}
+
+// eventTime is the time of a start or end, midnight local time for an all day event
+func eventTime(edt *calendar.EventDateTime) time.Time {
+	if edt == nil {
+		return time.Time{}
+	}
+	if edt.DateTime != "" {
+		t, _ := time.Parse(time.RFC3339, edt.DateTime)
+		return t
+	}
+	t, _ := time.ParseInLocation("2006-01-02", edt.Date, time.Local)
+	return t
+}
@@ -0,0 +1,130 @@
+// Package history keeps an append-only record of what the scheduler decided and how the joins went.
+// Records are stored one json object per line so the file can be read with the usual tools.
+package history
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// the events that are recorded
+const (
+	EventLaunch     = "launch"      // the cron handed the task off
+	EventSkipped    = "skipped"     // by request or because it was too old
+	EventRetry      = "retry"       // failed in a way that can work later
+	EventFailed     = "failed"      // the cron gave up on the task
+	EventJoined     = "joined"      // the meeting was joined
+	EventPrejoin    = "prejoin"     // stopped at the pre-join screen as asked
+	EventJoinFailed = "join_failed" // the browser server could not join
+)
+
+// Record is a line of the history
+type Record struct {
+	Time      time.Time  `json:"time"` // when it was recorded
+	Event     string     `json:"event"`
+	TaskID    string     `json:"task_id,omitempty"`
+	Name      string     `json:"name,omitempty"`
+	URI       string     `json:"uri,omitempty"`
+	Scheduled time.Time  `json:"scheduled"`        // the start of the meeting
+	Joined    *time.Time `json:"joined,omitempty"` // when the meeting was joined
+	Attempt   int        `json:"attempt,omitempty"`
+	Outcome   string     `json:"outcome,omitempty"` // the task state or the error code of a failed join
+	Error     string     `json:"error,omitempty"`
+}
+
+// Store is a json lines file the records are appended to, a nil Store records nothing
+type Store struct {
+	mu   sync.Mutex
+	path string
+}
+
+// Open returns the store at path, the file is created on the first append
+func Open(path string) (*Store, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	return &Store{path: path}, nil
+}
+
+// Append writes r to the end of the history, the time is set when it is empty
+func (s *Store) Append(r Record) error {
+	if s == nil {
+		return nil
+	}
+	if r.Time.IsZero() {
+		r.Time = time.Now()
+	}
+
+	b, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, err := os.OpenFile(s.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(b, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Query returns the records from from up to to in the order they were written, a zero time is open ended
+func (s *Store) Query(from, to time.Time) ([]Record, error) {
+	if s == nil {
+		return nil, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, err := os.Open(s.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var ret []Record
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1<<20)
+	for scanner.Scan() {
+		var r Record
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			continue // a line cut short by a crash, the rest of the file is fine
+		}
+		if !from.IsZero() && r.Time.Before(from) {
+			continue
+		}
+		if !to.IsZero() && !r.Time.Before(to) {
+			continue
+		}
+		ret = append(ret, r)
+	}
+	return ret, scanner.Err()
+}
+
+type storeKey struct{}
+
+// NewContext returns a copy of ctx that carries the store
+func NewContext(ctx context.Context, s *Store) context.Context {
+	return context.WithValue(ctx, storeKey{}, s)
+}
+
+// FromContext returns the store ctx carries, nil when there is none
+func FromContext(ctx context.Context) *Store {
+	s, _ := ctx.Value(storeKey{}).(*Store)
+	return s
+}
@@ -0,0 +1,88 @@
+package history
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestStore_Query(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "history.jsonl")
+	s, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+
+	// nothing was recorded yet
+	if got, err := s.Query(time.Time{}, time.Time{}); err != nil || len(got) != 0 {
+		t.Fatalf("Query() on an empty store = %v, %v", got, err)
+	}
+
+	day := time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)
+	joined := day.Add(time.Minute)
+	for _, r := range []Record{
+		{Time: day, Event: EventLaunch, TaskID: "a", Scheduled: day},
+		{Time: joined, Event: EventJoined, TaskID: "a", Scheduled: day, Joined: &joined, Outcome: "JOINED"},
+		{Time: day.Add(24 * time.Hour), Event: EventFailed, TaskID: "b", Error: "no browser"},
+	} {
+		if err := s.Append(r); err != nil {
+			t.Fatalf("Append() error = %v", err)
+		}
+	}
+
+	// a line cut short by a crash does not hide the rest
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		t.Fatalf("os.OpenFile() error = %v", err)
+	}
+	f.WriteString(`{"time":"2024-03-0`)
+	f.Close()
+
+	tests := []struct {
+		name     string
+		from, to time.Time
+		want     []string
+	}{
+		{"everything", time.Time{}, time.Time{}, []string{EventLaunch, EventJoined, EventFailed}},
+		{"one day", day, day.Add(24 * time.Hour), []string{EventLaunch, EventJoined}},
+		{"from is inclusive", joined, time.Time{}, []string{EventJoined, EventFailed}},
+		{"to is exclusive", time.Time{}, joined, []string{EventLaunch}},
+		{"nothing", day.Add(time.Hour), day.Add(2 * time.Hour), nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.Query(tt.from, tt.to)
+			if err != nil {
+				t.Fatalf("Query() error = %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Query() returned %d records, want %d", len(got), len(tt.want))
+			}
+			for i, r := range got {
+				if r.Event != tt.want[i] {
+					t.Errorf("Query()[%d] event = %s, want %s", i, r.Event, tt.want[i])
+				}
+			}
+		})
+	}
+
+	got, _ := s.Query(joined, joined.Add(time.Second))
+	if got[0].Joined == nil || !got[0].Joined.Equal(joined) || !got[0].Scheduled.Equal(day) {
+		t.Errorf("joined record = %+v, want the join and scheduled times back", got[0])
+	}
+}
+
+func TestStore_nil(t *testing.T) {
+	var s *Store
+	if err := s.Append(Record{Event: EventLaunch}); err != nil {
+		t.Errorf("Append() on a nil store error = %v", err)
+	}
+	if FromContext(context.Background()) != nil {
+		t.Errorf("FromContext() without a store should be nil")
+	}
+	if got, _ := s.Query(time.Time{}, time.Time{}); got != nil {
+		t.Errorf("Query() on a nil store = %v", got)
+	}
+}
@@ -1,4 +1,8 @@
 // Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v3.15.5
+// source: session.proto
 
 package manager
 
@@ -7,6 +11,7 @@ import (
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
 )
 
 // This is a compile-time assertion to ensure that this generated file
@@ -14,11 +19,23 @@ import (
 // Requires gRPC-Go v1.32.0 or later.
 const _ = grpc.SupportPackageIsVersion7
 
+const (
+	OpenMeetUrl_OpenMeetUrl_FullMethodName        = "/manager.OpenMeetUrl/OpenMeetUrl"
+	OpenMeetUrl_OpenMeetUrlEvents_FullMethodName  = "/manager.OpenMeetUrl/OpenMeetUrlEvents"
+	OpenMeetUrl_LeaveMeeting_FullMethodName       = "/manager.OpenMeetUrl/LeaveMeeting"
+	OpenMeetUrl_ListActiveMeetings_FullMethodName = "/manager.OpenMeetUrl/ListActiveMeetings"
+)
+
 // OpenMeetUrlClient is the client API for OpenMeetUrl service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type OpenMeetUrlClient interface {
+	// starts joining the meeting and returns the session id right away
 	OpenMeetUrl(ctx context.Context, in *Meet, opts ...grpc.CallOption) (*Status, error)
+	// same as OpenMeetUrl but streams each lifecycle step as it happens
+	OpenMeetUrlEvents(ctx context.Context, in *Meet, opts ...grpc.CallOption) (OpenMeetUrl_OpenMeetUrlEventsClient, error)
+	LeaveMeeting(ctx context.Context, in *SessionRequest, opts ...grpc.CallOption) (*Status, error)
+	ListActiveMeetings(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ActiveMeetings, error)
 }
 
 type openMeetUrlClient struct {
@@ -31,7 +48,57 @@ func NewOpenMeetUrlClient(cc grpc.ClientConnInterface) OpenMeetUrlClient {
 
 func (c *openMeetUrlClient) OpenMeetUrl(ctx context.Context, in *Meet, opts ...grpc.CallOption) (*Status, error) {
 	out := new(Status)
-	err := c.cc.Invoke(ctx, "/manager.OpenMeetUrl/OpenMeetUrl", in, out, opts...)
+	err := c.cc.Invoke(ctx, OpenMeetUrl_OpenMeetUrl_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *openMeetUrlClient) OpenMeetUrlEvents(ctx context.Context, in *Meet, opts ...grpc.CallOption) (OpenMeetUrl_OpenMeetUrlEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &OpenMeetUrl_ServiceDesc.Streams[0], OpenMeetUrl_OpenMeetUrlEvents_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &openMeetUrlOpenMeetUrlEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type OpenMeetUrl_OpenMeetUrlEventsClient interface {
+	Recv() (*MeetEvent, error)
+	grpc.ClientStream
+}
+
+type openMeetUrlOpenMeetUrlEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *openMeetUrlOpenMeetUrlEventsClient) Recv() (*MeetEvent, error) {
+	m := new(MeetEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *openMeetUrlClient) LeaveMeeting(ctx context.Context, in *SessionRequest, opts ...grpc.CallOption) (*Status, error) {
+	out := new(Status)
+	err := c.cc.Invoke(ctx, OpenMeetUrl_LeaveMeeting_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *openMeetUrlClient) ListActiveMeetings(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ActiveMeetings, error) {
+	out := new(ActiveMeetings)
+	err := c.cc.Invoke(ctx, OpenMeetUrl_ListActiveMeetings_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -42,7 +109,12 @@ func (c *openMeetUrlClient) OpenMeetUrl(ctx context.Context, in *Meet, opts ...g
 // All implementations must embed UnimplementedOpenMeetUrlServer
 // for forward compatibility
 type OpenMeetUrlServer interface {
+	// starts joining the meeting and returns the session id right away
 	OpenMeetUrl(context.Context, *Meet) (*Status, error)
+	// same as OpenMeetUrl but streams each lifecycle step as it happens
+	OpenMeetUrlEvents(*Meet, OpenMeetUrl_OpenMeetUrlEventsServer) error
+	LeaveMeeting(context.Context, *SessionRequest) (*Status, error)
+	ListActiveMeetings(context.Context, *emptypb.Empty) (*ActiveMeetings, error)
 	mustEmbedUnimplementedOpenMeetUrlServer()
 }
 
@@ -53,6 +125,15 @@ type UnimplementedOpenMeetUrlServer struct {
 func (UnimplementedOpenMeetUrlServer) OpenMeetUrl(context.Context, *Meet) (*Status, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method OpenMeetUrl not implemented")
 }
+func (UnimplementedOpenMeetUrlServer) OpenMeetUrlEvents(*Meet, OpenMeetUrl_OpenMeetUrlEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method OpenMeetUrlEvents not implemented")
+}
+func (UnimplementedOpenMeetUrlServer) LeaveMeeting(context.Context, *SessionRequest) (*Status, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LeaveMeeting not implemented")
+}
+func (UnimplementedOpenMeetUrlServer) ListActiveMeetings(context.Context, *emptypb.Empty) (*ActiveMeetings, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListActiveMeetings not implemented")
+}
 func (UnimplementedOpenMeetUrlServer) mustEmbedUnimplementedOpenMeetUrlServer() {}
 
 // UnsafeOpenMeetUrlServer may be embedded to opt out of forward compatibility for this service.
@@ -76,7 +157,7 @@ func _OpenMeetUrl_OpenMeetUrl_Handler(srv interface{}, ctx context.Context, dec
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/manager.OpenMeetUrl/OpenMeetUrl",
+		FullMethod: OpenMeetUrl_OpenMeetUrl_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(OpenMeetUrlServer).OpenMeetUrl(ctx, req.(*Meet))
@@ -84,6 +165,63 @@ func _OpenMeetUrl_OpenMeetUrl_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _OpenMeetUrl_OpenMeetUrlEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(Meet)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(OpenMeetUrlServer).OpenMeetUrlEvents(m, &openMeetUrlOpenMeetUrlEventsServer{stream})
+}
+
+type OpenMeetUrl_OpenMeetUrlEventsServer interface {
+	Send(*MeetEvent) error
+	grpc.ServerStream
+}
+
+type openMeetUrlOpenMeetUrlEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *openMeetUrlOpenMeetUrlEventsServer) Send(m *MeetEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _OpenMeetUrl_LeaveMeeting_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OpenMeetUrlServer).LeaveMeeting(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OpenMeetUrl_LeaveMeeting_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OpenMeetUrlServer).LeaveMeeting(ctx, req.(*SessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OpenMeetUrl_ListActiveMeetings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OpenMeetUrlServer).ListActiveMeetings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OpenMeetUrl_ListActiveMeetings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OpenMeetUrlServer).ListActiveMeetings(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // OpenMeetUrl_ServiceDesc is the grpc.ServiceDesc for OpenMeetUrl service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -95,6 +233,537 @@ var OpenMeetUrl_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "OpenMeetUrl",
 			Handler:    _OpenMeetUrl_OpenMeetUrl_Handler,
 		},
+		{
+			MethodName: "LeaveMeeting",
+			Handler:    _OpenMeetUrl_LeaveMeeting_Handler,
+		},
+		{
+			MethodName: "ListActiveMeetings",
+			Handler:    _OpenMeetUrl_ListActiveMeetings_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "OpenMeetUrlEvents",
+			Handler:       _OpenMeetUrl_OpenMeetUrlEvents_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "session.proto",
+}
+
+const (
+	Scheduler_ListSchedule_FullMethodName      = "/manager.Scheduler/ListSchedule"
+	Scheduler_GetNextTask_FullMethodName       = "/manager.Scheduler/GetNextTask"
+	Scheduler_SkipTask_FullMethodName          = "/manager.Scheduler/SkipTask"
+	Scheduler_SnoozeTask_FullMethodName        = "/manager.Scheduler/SnoozeTask"
+	Scheduler_ForceJoinTask_FullMethodName     = "/manager.Scheduler/ForceJoinTask"
+	Scheduler_ClearTaskOverride_FullMethodName = "/manager.Scheduler/ClearTaskOverride"
+	Scheduler_QueueMeeting_FullMethodName      = "/manager.Scheduler/QueueMeeting"
+	Scheduler_QueryHistory_FullMethodName      = "/manager.Scheduler/QueryHistory"
+	Scheduler_GetCalendarPoll_FullMethodName   = "/manager.Scheduler/GetCalendarPoll"
+)
+
+// SchedulerClient is the client API for Scheduler service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SchedulerClient interface {
+	ListSchedule(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Schedule, error)
+	GetNextTask(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ScheduledTask, error)
+	// overrides are kept across calendar polls until cleared
+	SkipTask(ctx context.Context, in *TaskRequest, opts ...grpc.CallOption) (*ScheduledTask, error)
+	SnoozeTask(ctx context.Context, in *SnoozeRequest, opts ...grpc.CallOption) (*ScheduledTask, error)
+	ForceJoinTask(ctx context.Context, in *TaskRequest, opts ...grpc.CallOption) (*ScheduledTask, error)
+	ClearTaskOverride(ctx context.Context, in *TaskRequest, opts ...grpc.CallOption) (*ScheduledTask, error)
+	// queued meetings are kept across calendar polls until they are over
+	QueueMeeting(ctx context.Context, in *QueueRequest, opts ...grpc.CallOption) (*ScheduledTask, error)
+	QueryHistory(ctx context.Context, in *HistoryRequest, opts ...grpc.CallOption) (*History, error)
+	GetCalendarPoll(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*CalendarPoll, error)
+}
+
+type schedulerClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSchedulerClient(cc grpc.ClientConnInterface) SchedulerClient {
+	return &schedulerClient{cc}
+}
+
+func (c *schedulerClient) ListSchedule(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Schedule, error) {
+	out := new(Schedule)
+	err := c.cc.Invoke(ctx, Scheduler_ListSchedule_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerClient) GetNextTask(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ScheduledTask, error) {
+	out := new(ScheduledTask)
+	err := c.cc.Invoke(ctx, Scheduler_GetNextTask_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerClient) SkipTask(ctx context.Context, in *TaskRequest, opts ...grpc.CallOption) (*ScheduledTask, error) {
+	out := new(ScheduledTask)
+	err := c.cc.Invoke(ctx, Scheduler_SkipTask_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerClient) SnoozeTask(ctx context.Context, in *SnoozeRequest, opts ...grpc.CallOption) (*ScheduledTask, error) {
+	out := new(ScheduledTask)
+	err := c.cc.Invoke(ctx, Scheduler_SnoozeTask_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerClient) ForceJoinTask(ctx context.Context, in *TaskRequest, opts ...grpc.CallOption) (*ScheduledTask, error) {
+	out := new(ScheduledTask)
+	err := c.cc.Invoke(ctx, Scheduler_ForceJoinTask_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerClient) ClearTaskOverride(ctx context.Context, in *TaskRequest, opts ...grpc.CallOption) (*ScheduledTask, error) {
+	out := new(ScheduledTask)
+	err := c.cc.Invoke(ctx, Scheduler_ClearTaskOverride_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerClient) QueueMeeting(ctx context.Context, in *QueueRequest, opts ...grpc.CallOption) (*ScheduledTask, error) {
+	out := new(ScheduledTask)
+	err := c.cc.Invoke(ctx, Scheduler_QueueMeeting_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerClient) QueryHistory(ctx context.Context, in *HistoryRequest, opts ...grpc.CallOption) (*History, error) {
+	out := new(History)
+	err := c.cc.Invoke(ctx, Scheduler_QueryHistory_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerClient) GetCalendarPoll(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*CalendarPoll, error) {
+	out := new(CalendarPoll)
+	err := c.cc.Invoke(ctx, Scheduler_GetCalendarPoll_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SchedulerServer is the server API for Scheduler service.
+// All implementations must embed UnimplementedSchedulerServer
+// for forward compatibility
+type SchedulerServer interface {
+	ListSchedule(context.Context, *emptypb.Empty) (*Schedule, error)
+	GetNextTask(context.Context, *emptypb.Empty) (*ScheduledTask, error)
+	// overrides are kept across calendar polls until cleared
+	SkipTask(context.Context, *TaskRequest) (*ScheduledTask, error)
+	SnoozeTask(context.Context, *SnoozeRequest) (*ScheduledTask, error)
+	ForceJoinTask(context.Context, *TaskRequest) (*ScheduledTask, error)
+	ClearTaskOverride(context.Context, *TaskRequest) (*ScheduledTask, error)
+	// queued meetings are kept across calendar polls until they are over
+	QueueMeeting(context.Context, *QueueRequest) (*ScheduledTask, error)
+	QueryHistory(context.Context, *HistoryRequest) (*History, error)
+	GetCalendarPoll(context.Context, *emptypb.Empty) (*CalendarPoll, error)
+	mustEmbedUnimplementedSchedulerServer()
+}
+
+// UnimplementedSchedulerServer must be embedded to have forward compatible implementations.
+type UnimplementedSchedulerServer struct {
+}
+
+func (UnimplementedSchedulerServer) ListSchedule(context.Context, *emptypb.Empty) (*Schedule, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSchedule not implemented")
+}
+func (UnimplementedSchedulerServer) GetNextTask(context.Context, *emptypb.Empty) (*ScheduledTask, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNextTask not implemented")
+}
+func (UnimplementedSchedulerServer) SkipTask(context.Context, *TaskRequest) (*ScheduledTask, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SkipTask not implemented")
+}
+func (UnimplementedSchedulerServer) SnoozeTask(context.Context, *SnoozeRequest) (*ScheduledTask, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SnoozeTask not implemented")
+}
+func (UnimplementedSchedulerServer) ForceJoinTask(context.Context, *TaskRequest) (*ScheduledTask, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ForceJoinTask not implemented")
+}
+func (UnimplementedSchedulerServer) ClearTaskOverride(context.Context, *TaskRequest) (*ScheduledTask, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClearTaskOverride not implemented")
+}
+func (UnimplementedSchedulerServer) QueueMeeting(context.Context, *QueueRequest) (*ScheduledTask, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueueMeeting not implemented")
+}
+func (UnimplementedSchedulerServer) QueryHistory(context.Context, *HistoryRequest) (*History, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryHistory not implemented")
+}
+func (UnimplementedSchedulerServer) GetCalendarPoll(context.Context, *emptypb.Empty) (*CalendarPoll, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCalendarPoll not implemented")
+}
+func (UnimplementedSchedulerServer) mustEmbedUnimplementedSchedulerServer() {}
+
+// UnsafeSchedulerServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SchedulerServer will
+// result in compilation errors.
+type UnsafeSchedulerServer interface {
+	mustEmbedUnimplementedSchedulerServer()
+}
+
+func RegisterSchedulerServer(s grpc.ServiceRegistrar, srv SchedulerServer) {
+	s.RegisterService(&Scheduler_ServiceDesc, srv)
+}
+
+func _Scheduler_ListSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServer).ListSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Scheduler_ListSchedule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServer).ListSchedule(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Scheduler_GetNextTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServer).GetNextTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Scheduler_GetNextTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServer).GetNextTask(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Scheduler_SkipTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServer).SkipTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Scheduler_SkipTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServer).SkipTask(ctx, req.(*TaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Scheduler_SnoozeTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SnoozeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServer).SnoozeTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Scheduler_SnoozeTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServer).SnoozeTask(ctx, req.(*SnoozeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Scheduler_ForceJoinTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServer).ForceJoinTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Scheduler_ForceJoinTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServer).ForceJoinTask(ctx, req.(*TaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Scheduler_ClearTaskOverride_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServer).ClearTaskOverride(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Scheduler_ClearTaskOverride_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServer).ClearTaskOverride(ctx, req.(*TaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Scheduler_QueueMeeting_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServer).QueueMeeting(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Scheduler_QueueMeeting_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServer).QueueMeeting(ctx, req.(*QueueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Scheduler_QueryHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServer).QueryHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Scheduler_QueryHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServer).QueryHistory(ctx, req.(*HistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Scheduler_GetCalendarPoll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServer).GetCalendarPoll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Scheduler_GetCalendarPoll_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServer).GetCalendarPoll(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Scheduler_ServiceDesc is the grpc.ServiceDesc for Scheduler service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Scheduler_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "manager.Scheduler",
+	HandlerType: (*SchedulerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListSchedule",
+			Handler:    _Scheduler_ListSchedule_Handler,
+		},
+		{
+			MethodName: "GetNextTask",
+			Handler:    _Scheduler_GetNextTask_Handler,
+		},
+		{
+			MethodName: "SkipTask",
+			Handler:    _Scheduler_SkipTask_Handler,
+		},
+		{
+			MethodName: "SnoozeTask",
+			Handler:    _Scheduler_SnoozeTask_Handler,
+		},
+		{
+			MethodName: "ForceJoinTask",
+			Handler:    _Scheduler_ForceJoinTask_Handler,
+		},
+		{
+			MethodName: "ClearTaskOverride",
+			Handler:    _Scheduler_ClearTaskOverride_Handler,
+		},
+		{
+			MethodName: "QueueMeeting",
+			Handler:    _Scheduler_QueueMeeting_Handler,
+		},
+		{
+			MethodName: "QueryHistory",
+			Handler:    _Scheduler_QueryHistory_Handler,
+		},
+		{
+			MethodName: "GetCalendarPoll",
+			Handler:    _Scheduler_GetCalendarPoll_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "session.proto",
+}
+
+const (
+	Dispatcher_RegisterAgent_FullMethodName = "/manager.Dispatcher/RegisterAgent"
+	Dispatcher_ListAgents_FullMethodName    = "/manager.Dispatcher/ListAgents"
+)
+
+// DispatcherClient is the client API for Dispatcher service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type DispatcherClient interface {
+	RegisterAgent(ctx context.Context, in *Agent, opts ...grpc.CallOption) (*AgentLease, error)
+	ListAgents(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*AgentList, error)
+}
+
+type dispatcherClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDispatcherClient(cc grpc.ClientConnInterface) DispatcherClient {
+	return &dispatcherClient{cc}
+}
+
+func (c *dispatcherClient) RegisterAgent(ctx context.Context, in *Agent, opts ...grpc.CallOption) (*AgentLease, error) {
+	out := new(AgentLease)
+	err := c.cc.Invoke(ctx, Dispatcher_RegisterAgent_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dispatcherClient) ListAgents(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*AgentList, error) {
+	out := new(AgentList)
+	err := c.cc.Invoke(ctx, Dispatcher_ListAgents_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DispatcherServer is the server API for Dispatcher service.
+// All implementations must embed UnimplementedDispatcherServer
+// for forward compatibility
+type DispatcherServer interface {
+	RegisterAgent(context.Context, *Agent) (*AgentLease, error)
+	ListAgents(context.Context, *emptypb.Empty) (*AgentList, error)
+	mustEmbedUnimplementedDispatcherServer()
+}
+
+// UnimplementedDispatcherServer must be embedded to have forward compatible implementations.
+type UnimplementedDispatcherServer struct {
+}
+
+func (UnimplementedDispatcherServer) RegisterAgent(context.Context, *Agent) (*AgentLease, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegisterAgent not implemented")
+}
+func (UnimplementedDispatcherServer) ListAgents(context.Context, *emptypb.Empty) (*AgentList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAgents not implemented")
+}
+func (UnimplementedDispatcherServer) mustEmbedUnimplementedDispatcherServer() {}
+
+// UnsafeDispatcherServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to DispatcherServer will
+// result in compilation errors.
+type UnsafeDispatcherServer interface {
+	mustEmbedUnimplementedDispatcherServer()
+}
+
+func RegisterDispatcherServer(s grpc.ServiceRegistrar, srv DispatcherServer) {
+	s.RegisterService(&Dispatcher_ServiceDesc, srv)
+}
+
+func _Dispatcher_RegisterAgent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Agent)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DispatcherServer).RegisterAgent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Dispatcher_RegisterAgent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DispatcherServer).RegisterAgent(ctx, req.(*Agent))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dispatcher_ListAgents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DispatcherServer).ListAgents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Dispatcher_ListAgents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DispatcherServer).ListAgents(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Dispatcher_ServiceDesc is the grpc.ServiceDesc for Dispatcher service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Dispatcher_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "manager.Dispatcher",
+	HandlerType: (*DispatcherServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "RegisterAgent",
+			Handler:    _Dispatcher_RegisterAgent_Handler,
+		},
+		{
+			MethodName: "ListAgents",
+			Handler:    _Dispatcher_ListAgents_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "session.proto",
@@ -0,0 +1,23 @@
+package session
+
+import (
+	"errors"
+	"fmt"
+)
+
+// the ways joining a meeting fails, session errors wrap one of these so callers can tell them apart with errors.Is
+var (
+	ErrLoginTimeout       = errors.New("timeout waiting for authentication")
+	ErrJoinButtonNotFound = errors.New("join button not found")
+	ErrBrowserLaunch      = errors.New("browser launch failed")
+	ErrNavigation         = errors.New("navigation failed")
+	ErrMeetingDenied      = errors.New("meeting denied")
+)
+
+// wrap tags err with the kind of failure, keeping the original message
+func wrap(kind error, err error) error {
+	if err == nil || errors.Is(err, kind) {
+		return err
+	}
+	return fmt.Errorf("%w: %w", kind, err)
+}
@@ -0,0 +1,44 @@
+package session
+
+import "time"
+
+// EventType is a step in the lifecycle of a meeting session
+type EventType int
+
+const (
+	EventLaunchingBrowser EventType = iota + 1
+	EventWaitingForLogin
+	EventLoggedIn
+	EventPageLoaded
+	EventSettingsApplied
+	EventJoined
+	EventLeft
+	EventError
+	EventPrejoin // stopped at the pre-join screen as the options asked
+)
+
+var eventNames = map[EventType]string{
+	EventLaunchingBrowser: "LAUNCHING_BROWSER",
+	EventWaitingForLogin:  "WAITING_FOR_LOGIN",
+	EventLoggedIn:         "LOGGED_IN",
+	EventPageLoaded:       "PAGE_LOADED",
+	EventSettingsApplied:  "SETTINGS_APPLIED",
+	EventJoined:           "JOINED",
+	EventLeft:             "LEFT",
+	EventError:            "ERROR",
+	EventPrejoin:          "PREJOIN",
+}
+
+func (e EventType) String() string {
+	if n, ok := eventNames[e]; ok {
+		return n
+	}
+	return "UNKNOWN"
+}
+
+// Event is emitted by a Session as it moves through a meeting
+type Event struct {
+	Type    EventType
+	Message string
+	Time    time.Time
+}
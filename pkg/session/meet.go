@@ -5,21 +5,39 @@ import (
 	"errors"
 	"os"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/chromedp/cdproto/cdp"
-	"github.com/chromedp/cdproto/input"
 	"github.com/chromedp/chromedp"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/codes"
 )
 
+const (
+	waitForJoinButton = time.Minute
+	waitForAdmission  = 2 * time.Minute
+)
+
+// admissionCheck reports "joined" once the call controls are on the page, "denied" when meet turned us away
+const admissionCheck = `(() => {
+	if (document.querySelector('[aria-label="Leave call"]')) { return "joined"; }
+	const text = document.body ? document.body.innerText : "";
+	if (/denied your request|no one responded to your request|can't join this (video )?call|removed from the meeting/i.test(text)) { return "denied"; }
+	return "";
+})()`
+
 /**
  * always give credit: https://github.com/perkeep/gphotos-cdp adaption
  */
 type Session struct {
+	mu            sync.Mutex // guards the parent context, Shutdown can be called from another routine
 	parentContext context.Context
 	parentCancel  context.CancelFunc
-	profileDir    string // user data session dir. automatically created on chrome startup.
+	closed        bool        // Shutdown was called, a browser started after it exits right away
+	profileDir    string      // user data session dir. automatically created on chrome startup.
+	onEvent       func(Event) // optional listener for lifecycle events
+	options       Options     // how to join
 }
 
 //NewSession a session to control the browser creation, creates a new browser if one is not running
@@ -47,8 +65,23 @@ func NewSession() (*Session, error) {
 
 }
 
-// Build a NewSession to open a meet uri
-func (s *Session) NewContext() (context.Context, context.CancelFunc) {
+// OnEvent registers a listener that is called for every lifecycle step of the session
+func (s *Session) OnEvent(fn func(Event)) {
+	s.onEvent = fn
+}
+
+// emit notifies the listener if there is one
+func (s *Session) emit(t EventType, msg string) {
+	if s.onEvent == nil {
+		return
+	}
+	s.onEvent(Event{Type: t, Message: msg, Time: time.Now()})
+}
+
+// Build a NewSession to open a meet uri, the browser contexts carry the values of parent such as the trace
+func (s *Session) NewContext(parent context.Context) (context.Context, context.CancelFunc) {
+	s.emit(EventLaunchingBrowser, s.profileDir)
+
 	// Let's use as a base for allocator options (It implies Headless)
 	opts := append(chromedp.DefaultExecAllocatorOptions[:],
 		chromedp.NoFirstRun,
@@ -62,16 +95,28 @@ func (s *Session) NewContext() (context.Context, context.CancelFunc) {
 	opts = append(opts, chromedp.Flag("mute-audio", false))
 	opts = append(opts, chromedp.Flag("disable-gpu", false))
 	opts = append(opts, chromedp.Flag("restore-on-startup", false))
-	opts = append(opts, chromedp.Flag("start-fullscreen", true))
+	opts = append(opts, chromedp.Flag("start-fullscreen", !s.options.Windowed))
 	opts = append(opts, chromedp.Flag("enable-automation", false))
 
-	ctx, cancel := chromedp.NewExecAllocator(context.Background(), opts...)
+	ctx, cancel := chromedp.NewExecAllocator(parent, opts...)
+	s.mu.Lock()
 	s.parentContext = ctx
 	s.parentCancel = cancel
-	ctx, cancel = chromedp.NewContext(s.parentContext)
+	if s.closed {
+		cancel()
+	}
+	s.mu.Unlock()
+	ctx, cancel = chromedp.NewContext(ctx)
 	return ctx, cancel
 }
 
+// Launch starts the browser for the context returned by NewContext
+func (s *Session) Launch(ctx context.Context) error {
+	return wrap(ErrBrowserLaunch, traced(ctx, "LAUNCH", func(ctx context.Context) error {
+		return chromedp.Run(ctx)
+	}))
+}
+
 //AddTab return another tab to navigate to
 func (s *Session) AddTab() (context.Context, context.CancelFunc) {
 	return chromedp.NewContext(s.parentContext)
@@ -80,7 +125,12 @@ func (s *Session) AddTab() (context.Context, context.CancelFunc) {
 //Shutdown calls the parent context to cancel
 func (s *Session) Shutdown() {
 	logrus.Info("Session is shutting down")
-	s.parentCancel()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	if s.parentCancel != nil {
+		s.parentCancel()
+	}
 }
 
 // login navigates to https://photos.google.com/ and waits for the user to have
@@ -92,7 +142,10 @@ func (s *Session) Login(ctx context.Context) error {
 	loggedInCheck := "//*/a[@role=\"button\"]/img/.."
 
 	var nodes []*cdp.Node
-	return chromedp.Run(ctx,
+	s.emit(EventWaitingForLogin, "")
+	ctx, span := tracer.Start(ctx, "session.LOGIN")
+	defer span.End()
+	err := chromedp.Run(ctx,
 		chromedp.ActionFunc(func(ctx context.Context) error {
 			logrus.Debugf("pre-navigate")
 			return nil
@@ -107,7 +160,7 @@ func (s *Session) Login(ctx context.Context) error {
 			var location string
 			for {
 				if time.Now().After(timeout) {
-					return errors.New("timeout waiting for authentication")
+					return ErrLoginTimeout
 				}
 
 				if err := chromedp.Nodes(loggedInCheck, &nodes, chromedp.AtLeast(0)).Do(ctx); err != nil {
@@ -131,12 +184,28 @@ func (s *Session) Login(ctx context.Context) error {
 			return nil
 		}),
 	)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		if errors.Is(err, context.DeadlineExceeded) {
+			return wrap(ErrLoginTimeout, err) // the caller gave up waiting for the user to log in
+		}
+		return wrap(ErrNavigation, err) // the wait loop tags its own timeout, anything else is the page failing
+	}
+
+	s.emit(EventLoggedIn, "")
+	return nil
 }
 
 // Open navigates to the meeturn uri and waits for the body to load.
 // Then turns off the mic, turns off the camera and joins the meeting
 func (s *Session) Open(ctx context.Context, meetURI string) error {
-	return s.execute(ctx, "OPEN", s.navigateUrl(meetURI))
+	if err := s.execute(ctx, "OPEN", s.navigateUrl(meetURI)); err != nil {
+		return wrap(ErrNavigation, err)
+	}
+
+	s.emit(EventPageLoaded, meetURI)
+	return nil
 }
 
 // navigateUrl opens the actual url
@@ -147,19 +216,18 @@ func (s *Session) navigateUrl(meetURI string) chromedp.Tasks {
 	}
 }
 
-// ApplySettings applies the preferences saved by the user such as shutting off mute and
+// ApplySettings applies the join options to the pre-join screen and joins unless asked to stay there
 func (s *Session) ApplySettings(ctx context.Context) error {
 
 	// find the button that contains a body of text by pulling the document into a format that can search the body of the message
 	// there are two ways that I'm thinking this can be done. goquery the node for the matching text, loop through each node and traverse the graph for the button that has the value
 	// - going to use goquery
 	//$x("/html/body//span/text()[contains(translate(., 'ABCDEFGHIJKLMNOPQRSTUVWXYZ', 'abcdefghijklmnopqrstuvwxyz'),'join')]")
-	selector := "//span/text()[contains(translate(., 'ABCDEFGHIJKLMNOPQRSTUVWXYZ', 'abcdefghijklmnopqrstuvwxyz'),'join now')]/.."
+	selector := "//span/text()[contains(translate(., 'ABCDEFGHIJKLMNOPQRSTUVWXYZ', 'abcdefghijklmnopqrstuvwxyz'),'join now') or contains(translate(., 'ABCDEFGHIJKLMNOPQRSTUVWXYZ', 'abcdefghijklmnopqrstuvwxyz'),'ask to join')]/.."
 
 	tasks := chromedp.Tasks{
 		chromedp.Sleep(1 * time.Second),
-		input.DispatchKeyEvent(input.KeyDown).WithModifiers(input.ModifierMeta).WithKey(`d`),
-		input.DispatchKeyEvent(input.KeyDown).WithModifiers(input.ModifierMeta).WithKey(`e`),
+		chromedp.ActionFunc(s.applyOptions),
 		chromedp.Sleep(1 * time.Second),
 	}
 
@@ -167,9 +235,19 @@ func (s *Session) ApplySettings(ctx context.Context) error {
 		logrus.Warnf("SETTINGS ERROR: %s\n", err)
 		return err
 	}
+	s.emit(EventSettingsApplied, s.options.String())
 
-	if err := chromedp.Run(ctx,
-		chromedp.ActionFunc(func(ctx context.Context) error {
+	if s.options.StayOnPrejoin {
+		s.emit(EventPrejoin, "")
+		return nil
+	}
+
+	// the click waits for the button to show up, do not wait forever for a page without one
+	clickCtx, cancel := context.WithTimeout(ctx, waitForJoinButton)
+	defer cancel()
+
+	if err := traced(clickCtx, "JOIN", func(clickCtx context.Context) error {
+		return chromedp.Run(clickCtx, chromedp.ActionFunc(func(ctx context.Context) error {
 			logrus.Debugf("pre-click")
 			err := chromedp.Click(selector, chromedp.BySearch).Do(ctx)
 			if err != nil {
@@ -177,16 +255,46 @@ func (s *Session) ApplySettings(ctx context.Context) error {
 			}
 			logrus.Debugf("post-click")
 			return nil
-		})); err != nil {
+		}))
+	}); err != nil {
 		logrus.Debugf("CLICK ERROR: %s\n", err)
-		return err
+		return wrap(ErrJoinButtonNotFound, err)
 
 	}
 
+	if err := traced(ctx, "ADMISSION", s.waitAdmitted); err != nil {
+		return err
+	}
+
+	s.emit(EventJoined, "")
 	return nil
 
 }
 
+// waitAdmitted watches the page after asking to join for the meeting to let us in or turn us away.
+// When neither shows up in time we assume we are in, the in call page changes more often than the denial.
+func (s *Session) waitAdmitted(ctx context.Context) error {
+	var state string
+	timeout := time.Now().Add(waitForAdmission)
+	for time.Now().Before(timeout) {
+		if err := chromedp.Run(ctx, chromedp.Evaluate(admissionCheck, &state)); err != nil {
+			return err
+		}
+
+		switch state {
+		case "joined":
+			return nil
+		case "denied":
+			return ErrMeetingDenied
+		}
+
+		time.Sleep(time.Second)
+	}
+
+	logrus.Warnf("Could not confirm the meeting was joined, assuming it was")
+	return nil
+}
+
 // execute runs tasks
 func (s *Session) execute(ctx context.Context, actionType string, actions chromedp.Tasks) error {
 
@@ -211,14 +319,16 @@ func (s *Session) execute(ctx context.Context, actionType string, actions chrome
 			}),
 	})
 
-	err := chromedp.Run(ctx, actions)
-	return err
+	return traced(ctx, actionType, func(ctx context.Context) error {
+		return chromedp.Run(ctx, actions)
+	})
 
 }
 
 // wait for the browser to exit
 func (s *Session) Wait(ctx context.Context) {
 	logrus.Infof("Waiting for the browser to exit")
+	defer s.emit(EventLeft, "")
 	defer s.Shutdown()
 	for {
 		select {
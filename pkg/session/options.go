@@ -0,0 +1,105 @@
+package session
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"time"
+
+	"github.com/chromedp/cdproto/input"
+	"github.com/chromedp/chromedp"
+	"github.com/sirupsen/logrus"
+)
+
+// Options is how to join a meeting, the zero value joins muted with the camera off in fullscreen
+type Options struct {
+	MicOn         bool
+	CameraOn      bool
+	DisplayName   string // typed into the guest name box, meet only shows it when not logged in
+	Windowed      bool   // the browser starts fullscreen unless set
+	StayOnPrejoin bool   // apply the settings and stop before clicking join
+}
+
+func (o Options) String() string {
+	return fmt.Sprintf("mic: %t camera: %t name: %q windowed: %t prejoin: %t", o.MicOn, o.CameraOn, o.DisplayName, o.Windowed, o.StayOnPrejoin)
+}
+
+// SetOptions sets how the session joins, call it before NewContext
+func (s *Session) SetOptions(o Options) {
+	s.options = o
+}
+
+// deviceToggle sets the mic or camera button of the pre-join screen to the wanted state.
+// The buttons carry data-is-muted, so the state is read before clicking instead of blindly toggling.
+// It returns "missing" when the button is not on the page.
+const deviceToggle = `((device, on) => {
+	const btn = [...document.querySelectorAll('[data-is-muted]')]
+		.find(b => (b.getAttribute('aria-label') || '').toLowerCase().includes(device));
+	if (!btn) { return "missing"; }
+	if ((btn.getAttribute('data-is-muted') !== 'true') !== on) { btn.click(); }
+	return "ok";
+})(%q, %t)`
+
+// guestName fills in the name box meet shows to guests, it returns false when there is none
+const guestName = `((name) => {
+	const box = document.querySelector('input[aria-label="Your name"]');
+	if (!box) { return false; }
+	box.focus();
+	box.value = name;
+	box.dispatchEvent(new Event('input', { bubbles: true }));
+	return true;
+})(%q)`
+
+// applyOptions puts the pre-join screen in the state the options ask for, it runs as an action
+func (s *Session) applyOptions(ctx context.Context) error {
+	if err := s.setDevice(ctx, "microphone", "d", s.options.MicOn); err != nil {
+		return err
+	}
+	if err := s.setDevice(ctx, "camera", "e", s.options.CameraOn); err != nil {
+		return err
+	}
+
+	if s.options.DisplayName == "" {
+		return nil
+	}
+
+	var found bool
+	if err := chromedp.Evaluate(fmt.Sprintf(guestName, s.options.DisplayName), &found).Do(ctx); err != nil {
+		return err
+	}
+	if !found {
+		logrus.Infof("No guest name box on the page, joining as the logged in user")
+	}
+	return nil
+}
+
+// setDevice turns the device on or off. When the page has no toggle to read, the keyboard shortcut
+// is used to turn it off, meet starts with the devices on, and an on request is left alone.
+func (s *Session) setDevice(ctx context.Context, device, key string, on bool) error {
+	var state string
+	if err := chromedp.Evaluate(fmt.Sprintf(deviceToggle, device, on), &state).Do(ctx); err != nil {
+		return err
+	}
+
+	if state != "missing" {
+		return nil
+	}
+
+	logrus.Warnf("No %s toggle on the page", device)
+	if on {
+		return nil
+	}
+
+	if err := input.DispatchKeyEvent(input.KeyDown).WithModifiers(shortcutModifier()).WithKey(key).Do(ctx); err != nil {
+		return err
+	}
+	return chromedp.Sleep(500 * time.Millisecond).Do(ctx)
+}
+
+// shortcutModifier is cmd on a mac and ctrl everywhere else
+func shortcutModifier() input.Modifier {
+	if runtime.GOOS == "darwin" {
+		return input.ModifierMeta
+	}
+	return input.ModifierCtrl
+}
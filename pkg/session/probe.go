@@ -0,0 +1,30 @@
+package session
+
+import (
+	"context"
+	"os"
+
+	"github.com/chromedp/chromedp"
+)
+
+// Probe starts a headless browser with a throwaway profile and closes it again, it fails the way Launch would.
+// The profile of the meetings is left alone so a probe does not get in the way of a session.
+func Probe(ctx context.Context) error {
+	dir, err := os.MkdirTemp("", "meet-probe")
+	if err != nil {
+		return wrap(ErrBrowserLaunch, err)
+	}
+	defer os.RemoveAll(dir)
+
+	opts := append(chromedp.DefaultExecAllocatorOptions[:],
+		chromedp.NoFirstRun,
+		chromedp.NoDefaultBrowserCheck,
+		chromedp.UserDataDir(dir),
+	)
+	ctx, cancel := chromedp.NewExecAllocator(ctx, opts...)
+	defer cancel()
+	ctx, cancel = chromedp.NewContext(ctx)
+	defer cancel()
+
+	return wrap(ErrBrowserLaunch, chromedp.Run(ctx))
+}
@@ -0,0 +1,23 @@
+package session
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+)
+
+var tracer = otel.Tracer("github.com/dathan/go-grpc-video-call-manager/pkg/session")
+
+// traced runs a browser action in a span named after it, ctx is a chromedp context
+func traced(ctx context.Context, action string, fn func(context.Context) error) error {
+	ctx, span := tracer.Start(ctx, "session."+action)
+	defer span.End()
+
+	err := fn(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
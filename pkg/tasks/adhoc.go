@@ -0,0 +1,90 @@
+package tasks
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ErrDuplicateTask is returned when the task is already queued
+var ErrDuplicateTask = errors.New("task is already queued")
+
+// ErrTaskOver is returned when the task ended before it was queued
+var ErrTaskOver = errors.New("task is already over")
+
+// Queue adds a task that is not on the calendar. It is kept across calendar updates until it is over
+// and runs like any other task, it returns the TaskID.
+func (c *Cron) Queue(t Task) (string, error) {
+	id := TaskID(t)
+
+	c.sLock.Lock()
+	if expired(t) {
+		c.sLock.Unlock()
+		return "", ErrTaskOver
+	}
+	for _, q := range c.adhoc {
+		if TaskID(q) == id {
+			c.sLock.Unlock()
+			return "", ErrDuplicateTask
+		}
+	}
+
+	logrus.Infof("Queued task: %s starts: %s", t.Name(), t.Start())
+	c.adhoc = append(c.adhoc, t)
+	c.sLock.Unlock()
+
+	c.resume()
+	return id, nil
+}
+
+// isAdhoc is true when the task was queued by hand, callers hold sLock
+func (c *Cron) isAdhoc(id string) bool {
+	for _, q := range c.adhoc {
+		if TaskID(q) == id {
+			return true
+		}
+	}
+	return false
+}
+
+// merge returns st with the queued tasks it does not have yet in start order, callers hold sLock.
+// Queued tasks that are over are forgotten.
+func (c *Cron) merge(st SequentialTasks) SequentialTasks {
+	if len(c.adhoc) == 0 {
+		return st
+	}
+
+	live := c.adhoc[:0]
+	for _, q := range c.adhoc {
+		if !expired(q) {
+			live = append(live, q)
+		}
+	}
+	c.adhoc = live
+
+	seen := map[string]bool{}
+	ret := make(SequentialTasks, 0, len(st)+len(c.adhoc))
+	for _, t := range st {
+		seen[TaskID(t)] = true
+		ret = append(ret, t)
+	}
+	for _, q := range c.adhoc {
+		if !seen[TaskID(q)] {
+			ret = append(ret, q)
+		}
+	}
+
+	sort.SliceStable(ret, func(i, j int) bool { return ret[i].Start().Before(ret[j].Start()) })
+	return ret
+}
+
+// expired is true once the task ended, a task without an end is kept for a day
+func expired(t Task) bool {
+	end := t.End()
+	if end.IsZero() {
+		end = t.Start().Add(24 * time.Hour)
+	}
+	return time.Now().After(end)
+}
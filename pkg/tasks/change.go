@@ -0,0 +1,104 @@
+package tasks
+
+import (
+	"context"
+	"sort"
+
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Change removes and adds tasks on the schedule in place, the rest of the schedule and the queued tasks are
+// left alone. The states and overrides of removed tasks are dropped, a task replaced under the same TaskID keeps
+// them. ctx carries the trace of the calendar poll like for Update.
+func (c *Cron) Change(ctx context.Context, add SequentialTasks, remove []string) {
+	if len(add) == 0 && len(remove) == 0 {
+		return
+	}
+
+	c.tLock.Lock()
+	c.sLock.Lock()
+	c.poll = trace.SpanContextFromContext(ctx)
+	c.ordered = c.merge(c.changed(add, remove))
+	for _, id := range remove {
+		if c.find(id) == nil { // a queued task with the id stays
+			delete(c.states, id)
+			delete(c.overrides, id)
+		}
+	}
+	c.forget()
+	c.sLock.Unlock()
+	c.tLock.Unlock()
+
+	c.resume()
+}
+
+// resume looks at the tasks again, a waiting cron is woken up and an idle one started
+func (c *Cron) resume() {
+	c.sLock.Lock()
+	idle := c.isIdle
+	c.isIdle = false
+	c.sLock.Unlock()
+
+	if idle {
+		go c.Run()
+		return
+	}
+	c.Wake()
+}
+
+// changed returns the ordered tasks with the changes in start order, tasks that are over are dropped, callers
+// hold sLock
+func (c *Cron) changed(add SequentialTasks, remove []string) SequentialTasks {
+	drop := map[string]bool{}
+	for _, id := range remove {
+		drop[id] = true
+	}
+	added := map[string]Task{}
+	for _, t := range add {
+		added[TaskID(t)] = t
+	}
+
+	ret := make(SequentialTasks, 0, len(c.ordered)+len(add))
+	for _, t := range c.ordered {
+		id := TaskID(t)
+		switch {
+		case drop[id]:
+			logrus.Infof("Removing task: %s", t.Name())
+		case added[id] != nil:
+			if !t.End().Equal(added[id].End()) { // the name and start are the id, only the end can move
+				logrus.Infof("Replacing task: %s ends: %s", t.Name(), added[id].End())
+			}
+			ret = append(ret, added[id])
+			delete(added, id)
+		case expired(t):
+			// over, the calendar stops listing it
+		default:
+			ret = append(ret, t)
+		}
+	}
+	for _, t := range add {
+		if id := TaskID(t); added[id] != nil {
+			logrus.Infof("Adding task: %s starts: %s", t.Name(), t.Start())
+			ret = append(ret, t)
+			delete(added, id)
+		}
+	}
+
+	sort.SliceStable(ret, func(i, j int) bool { return ret[i].Start().Before(ret[j].Start()) })
+	return ret
+}
+
+// Tasks returns the tasks on the schedule that were not queued by hand, the ones a calendar update manages
+func (c *Cron) Tasks() SequentialTasks {
+	c.sLock.Lock()
+	defer c.sLock.Unlock()
+
+	var ret SequentialTasks
+	for _, t := range c.ordered {
+		if !c.isAdhoc(TaskID(t)) {
+			ret = append(ret, t)
+		}
+	}
+	return ret
+}
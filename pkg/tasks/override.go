@@ -0,0 +1,136 @@
+package tasks
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ErrUnknownTask is returned when an override names a task the cron does not have
+var ErrUnknownTask = errors.New("unknown task")
+
+// ErrSnoozeTooLong is returned for a snooze that launches the task after it is too old to join
+var ErrSnoozeTooLong = errors.New("snooze too long")
+
+// MaxSnooze is the longest snooze. A task launches MAGIC_DELTA before its start and is too old to join
+// MAGIC_DELTA after it, the timer can be a TIMER_TICK late and another TIMER_TICK is left for the launch.
+const MaxSnooze = (2*time.Duration(MAGIC_DELTA) - 2*TIMER_TICK) * time.Second
+
+// Override changes how the cron treats a single task
+type Override int
+
+const (
+	OverrideNone Override = iota
+	OverrideSkip
+	OverrideSnooze
+	OverrideForce
+)
+
+var overrideNames = map[Override]string{
+	OverrideNone:   "NONE",
+	OverrideSkip:   "SKIP",
+	OverrideSnooze: "SNOOZE",
+	OverrideForce:  "FORCE_JOIN",
+}
+
+func (o Override) String() string {
+	return overrideNames[o]
+}
+
+// taskOverride is kept by TaskID so it outlives the task list being replaced by a calendar poll
+type taskOverride struct {
+	kind   Override
+	snooze time.Duration
+	start  time.Time
+}
+
+// Skip makes the cron pass over the task
+func (c *Cron) Skip(id string) error {
+	return c.setOverride(id, OverrideSkip, 0)
+}
+
+// Snooze delays the launch of the task by d, at most MaxSnooze. The snooze counts from the launch
+// MAGIC_DELTA before the start, not from the start.
+func (c *Cron) Snooze(id string, d time.Duration) error {
+	if d > MaxSnooze {
+		return fmt.Errorf("%w: %s is past the %s the task can be late", ErrSnoozeTooLong, d, MaxSnooze)
+	}
+	return c.setOverride(id, OverrideSnooze, d)
+}
+
+// ForceJoin runs the task now, even if it already ran
+func (c *Cron) ForceJoin(id string) error {
+	if err := c.setOverride(id, OverrideForce, 0); err != nil {
+		return err
+	}
+
+	c.sLock.Lock()
+	delete(c.states, id)
+	c.sLock.Unlock()
+
+	c.Wake()
+	return nil
+}
+
+// ClearOverride puts the task back on its calendar schedule
+func (c *Cron) ClearOverride(id string) error {
+	c.sLock.Lock()
+	defer c.sLock.Unlock()
+	if c.find(id) == nil {
+		return ErrUnknownTask
+	}
+	delete(c.overrides, id)
+	return nil
+}
+
+// Wake makes a waiting cron evaluate its tasks now instead of at the next timer
+func (c *Cron) Wake() {
+	select {
+	case c.wake <- struct{}{}:
+	default: // a wake up is already pending
+	}
+}
+
+func (c *Cron) setOverride(id string, kind Override, d time.Duration) error {
+	c.sLock.Lock()
+	defer c.sLock.Unlock()
+
+	t := c.find(id)
+	if t == nil {
+		return ErrUnknownTask
+	}
+
+	logrus.Infof("Override %s for task: %s (%s)", kind, t.Name(), d)
+	c.overrides[id] = &taskOverride{kind: kind, snooze: d, start: t.Start()}
+	return nil
+}
+
+// find returns the task with the id, callers hold sLock
+func (c *Cron) find(id string) Task {
+	for _, t := range c.merge(c.ordered) {
+		if TaskID(t) == id {
+			return t
+		}
+	}
+	return nil
+}
+
+// override returns the override of the task, an empty one if there is none
+func (c *Cron) override(t Task) taskOverride {
+	c.sLock.Lock()
+	defer c.sLock.Unlock()
+	if o, ok := c.overrides[TaskID(t)]; ok {
+		return *o
+	}
+	return taskOverride{}
+}
+
+// fireTime is when the cron launches the task once its override is applied
+func (o taskOverride) fireTime(t Task) time.Time {
+	if o.kind == OverrideSnooze {
+		return FireTime(t).Add(o.snooze)
+	}
+	return FireTime(t)
+}
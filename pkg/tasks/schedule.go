@@ -2,25 +2,37 @@ package tasks
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"reflect"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/dathan/go-grpc-video-call-manager/internal/utils"
+	"github.com/dathan/go-grpc-video-call-manager/pkg/history"
 	"github.com/google/go-cmp/cmp"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
+var tracer = otel.Tracer("github.com/dathan/go-grpc-video-call-manager/pkg/tasks")
+
 // TODO: turn into a pref
 const MAGIC_DELTA = float64(600) // seconds
 
+// a waiting cron looks at its timer this often, a launch can be this late
+const TIMER_TICK = 60 // seconds
+
 // Things that satisfy this interface can be executed as a Task
 type Task interface {
 	Start() time.Time
 	Name() string
 	End() time.Time
-	Execute(*utils.Config) error //A Task has to be able to be run
+	Execute(context.Context, *utils.Config) error //A Task has to be able to be run, ctx carries the trace of the cron pass
 }
 
 // Tasks that are sequentially executed
@@ -31,13 +43,21 @@ type Cron struct {
 	ordered       SequentialTasks
 	parentContext context.Context
 	taskChan      chan SequentialTasks
+	wake          chan struct{}
 	tLock         *sync.Mutex
+	sLock         *sync.Mutex // guards states and writes to ordered so a snapshot does not wait on a running task
 	currentTask   *Task
 	lastTask      *Task
+	states        map[string]*taskStatus   // tasks already handed off keyed by TaskID
+	overrides     map[string]*taskOverride // skip, snooze and force requests keyed by TaskID
+	adhoc         SequentialTasks          // queued by hand, merged into every calendar update
+	poll          trace.SpanContext        // the calendar poll behind the last update, a pass links to it
 	jobCount      int64
 	isRunning     bool
 	isListening   bool
+	isIdle        bool // Run returned without a wait, a change has to start it again
 	Config        *utils.Config
+	History       *history.Store // where the decisions are recorded, nil records nothing
 }
 
 // Create a new timed task
@@ -46,7 +66,11 @@ func NewCron(ctx context.Context, mis SequentialTasks, config *utils.Config) *Cr
 		ordered:       mis,
 		parentContext: ctx,
 		taskChan:      make(chan SequentialTasks),
+		wake:          make(chan struct{}, 1),
 		tLock:         &sync.Mutex{},
+		sLock:         &sync.Mutex{},
+		states:        map[string]*taskStatus{},
+		overrides:     map[string]*taskOverride{},
 		Config:        config,
 	}
 }
@@ -63,6 +87,13 @@ func (c *Cron) Run() {
 	c.tLock.Lock()
 	//defer c.tLock.Unlock()
 
+	// pick up the tasks queued since the last pass
+	c.sLock.Lock()
+	c.ordered = c.merge(c.ordered)
+	idle := len(c.ordered) == 0
+	c.isIdle = idle
+	c.sLock.Unlock()
+
 	/*
 		//when run finishes clean up the routine
 		ctxRun, cancel := context.WithCancel(c.parentContext)
@@ -71,54 +102,146 @@ func (c *Cron) Run() {
 		go c.listenForUpdates(ctxRun)
 	*/
 
-	if len(c.ordered) == 0 {
+	if idle {
 		logrus.Warn("Tasks are finished")
 		c.tLock.Unlock()
 		return
 	}
 
+	// the span covers the pass over the tasks, not the wait for the next one
+	c.sLock.Lock()
+	poll := c.poll
+	c.sLock.Unlock()
+	ctx, span := tracer.Start(c.parentContext, "cron.run",
+		trace.WithAttributes(attribute.Int("tasks", len(c.ordered))),
+		trace.WithLinks(trace.Link{SpanContext: poll}),
+	)
+
 	// from a golang concurrency perspective range produces a write to assign it to task.
-	// c.ordered is order in time and we will either execute the task or schedule the task to run
+	// c.ordered is order in time and we will either execute the task or schedule the task to run.
+	// overrides can move a task out of order so every task is looked at and the soonest one is scheduled
 	var d time.Duration
+	var next Task
+	retry := false
 	for _, task := range c.ordered {
 
 		logrus.Infof("%d] Looking at task: %+v", c.jobCount, task)
 
 		c.currentTask = nil
 		c.isRunning = false
-		s := time.Now().Add(time.Second * time.Duration(MAGIC_DELTA)) // if now+10min is after task start
-		if s.After(task.Start()) {                                    // handle if the task already started
+
+		// joining returns once the meeting is up, do not join it again on the next pass
+		if c.hasRun(task) {
+			continue
+		}
+
+		ov := c.override(task)
+		if ov.kind == OverrideSkip {
+			logrus.Infof("Task: %s - skipped by request", task.Name())
+			err := fmt.Errorf("skipped by request: %w", ErrSkipped)
+			c.setState(task, StateSkipped, err)
+			c.record(task, history.EventSkipped, StateSkipped, 0, err)
+			continue
+		}
+
+		fire := ov.fireTime(task) // if now is after start-10min (plus a snooze) the task is due
+		if ov.kind == OverrideForce || time.Now().After(fire) {
 
 			c.currentTask = &task
 			c.isRunning = true
-			if err := task.Execute(c.Config); err != nil {
+			attempts := c.setState(task, StateRunning, nil)
+			c.record(task, history.EventLaunch, StateRunning, attempts, nil)
+			if err := c.execute(ctx, task, attempts); err != nil {
 				logrus.Warnf("Task: %s - ERROR - %s\n", task, err)
+				switch {
+				case errors.Is(err, ErrSkipped):
+					c.setState(task, StateSkipped, err)
+					c.record(task, history.EventSkipped, StateSkipped, attempts, err)
+				case errors.Is(err, ErrRetryable) && attempts < MAX_ATTEMPTS:
+					logrus.Infof("Task: %s - retrying in %d seconds (attempt %d of %d)", task.Name(), RETRY_DELAY, attempts, MAX_ATTEMPTS)
+					c.setState(task, StatePending, err)
+					c.record(task, history.EventRetry, StatePending, attempts, err)
+					retry = true
+				default:
+					c.setState(task, StateFailed, err)
+					c.record(task, history.EventFailed, StateFailed, attempts, err)
+				}
 			}
+			c.isRunning = false
 
 			continue // notice we will continue iterating the next task lisk
 		}
 
-		c.lastTask = &task
-
 		//TODO: make preference of scheduling the task buffer
-		d = task.Start().Sub(s)
-		logrus.Infof("NEW SCHEDULED START[ %s => %+v ] - Timer Execution: %f seconds -> %s", task.Name(), task.Start(), d.Seconds(), time.Now().Add(d))
-		break
+		if wait := time.Until(fire); next == nil || wait < d {
+			next = task
+			d = wait
+		}
+	}
+
+	if next != nil {
+		c.lastTask = &next
+		logrus.Infof("NEW SCHEDULED START[ %s => %+v ] - Timer Execution: %f seconds -> %s", next.Name(), next.Start(), d.Seconds(), time.Now().Add(d))
 	}
 
+	// come back early for a task that failed in a way that can work later
+	if delay := time.Duration(RETRY_DELAY) * time.Second; retry && (next == nil || d > delay) {
+		d = delay
+	}
+
+	span.End()
 	c.tLock.Unlock()
 	c.wait(d)
 	logrus.Info("Run finished")
 
 }
 
+// execute runs the task in its own span
+func (c *Cron) execute(ctx context.Context, task Task, attempt int) error {
+	ctx, span := tracer.Start(ctx, "cron.execute", trace.WithAttributes(
+		attribute.String("task.id", TaskID(task)),
+		attribute.String("task.name", task.Name()),
+		attribute.Int("task.attempt", attempt),
+	))
+	defer span.End()
+
+	err := task.Execute(history.NewContext(ctx, c.History), c.Config)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+// record appends a decision about the task to the history
+func (c *Cron) record(task Task, event string, state TaskState, attempt int, err error) {
+	r := history.Record{
+		Event:     event,
+		TaskID:    TaskID(task),
+		Name:      task.Name(),
+		Scheduled: task.Start(),
+		Attempt:   attempt,
+		Outcome:   state.String(),
+	}
+	if l, ok := task.(interface{ URI() string }); ok {
+		r.URI = l.URI()
+	}
+	if err != nil {
+		r.Error = err.Error()
+	}
+
+	if err := c.History.Append(r); err != nil {
+		logrus.Errorf("Could not record %s of %s: %v", event, task.Name(), err)
+	}
+}
+
 func (c *Cron) handleLaptopSleepTimer(timerDuration time.Duration) {
 
 	// Calculate the target time from now
 	targetTime := time.Now().Add(timerDuration)
 
 	// Use a ticker to periodically check the time
-	ticker := time.NewTicker(1 * time.Minute)
+	ticker := time.NewTicker(time.Duration(TIMER_TICK) * time.Second)
 	defer ticker.Stop()
 
 	logrus.Infof("We will return at: %s\n", targetTime)
@@ -131,6 +254,10 @@ func (c *Cron) handleLaptopSleepTimer(timerDuration time.Duration) {
 				go c.Run()
 				return
 			}
+		case <-c.wake: // an override wants the tasks looked at now
+			logrus.Info("Timer woken up")
+			go c.Run()
+			return
 		case <-c.taskChan: // listen for an update to the calendar
 			logrus.Infof("Timer task chan detected update")
 			return
@@ -141,11 +268,16 @@ func (c *Cron) handleLaptopSleepTimer(timerDuration time.Duration) {
 	}
 }
 
-// Update the tasks channel so listeners can execute the new job order
-func (c *Cron) Update(st SequentialTasks) {
+// Update the tasks channel so listeners can execute the new job order, ctx carries the trace of the calendar poll
+func (c *Cron) Update(ctx context.Context, st SequentialTasks) {
 
 	//logrus.Infof("Next Task: %s => Starts: %s", c.ordered[0].Name(), c.ordered[0].Start().Sub(time.Now()))
 	// TODO: this is a race condition locking the subsystem. Do not do updates when connected to a meeting
+	c.sLock.Lock()
+	st = c.merge(st) // the queued tasks survive the calendar replacing the list
+	c.poll = trace.SpanContextFromContext(ctx)
+	c.sLock.Unlock()
+
 	if !c.isRunning && !cmp.Equal(c.ordered, st) {
 		logrus.Info("Updating the channel to replace the task list")
 		go c.listenForUpdates(c.parentContext) // make sure there is always a listener
@@ -229,8 +361,25 @@ func (c *Cron) waitOrig(t *time.Timer) {
 func (c *Cron) internalUpdate(st SequentialTasks) {
 	c.tLock.Lock()
 	defer c.tLock.Unlock()
-	c.ordered = st
-	return
+	c.sLock.Lock()
+	defer c.sLock.Unlock()
+	c.ordered = c.merge(st)
+	c.forget()
+}
+
+// forget drops the states and overrides of the tasks that are long gone so the maps do not grow forever,
+// callers hold sLock
+func (c *Cron) forget() {
+	for k, status := range c.states {
+		if time.Since(status.start) > 24*time.Hour {
+			delete(c.states, k)
+		}
+	}
+	for k, o := range c.overrides {
+		if time.Since(o.start) > 24*time.Hour {
+			delete(c.overrides, k)
+		}
+	}
 }
 
 func CloneValue(source interface{}, destin interface{}) {
@@ -0,0 +1,330 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dathan/go-grpc-video-call-manager/internal/utils"
+	"github.com/dathan/go-grpc-video-call-manager/pkg/history"
+	"github.com/google/go-cmp/cmp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// fakeTask records how often it ran and returns err
+type fakeTask struct {
+	name  string
+	start time.Time
+	err   error
+	runs  int
+}
+
+func (f *fakeTask) Start() time.Time { return f.start }
+func (f *fakeTask) End() time.Time   { return f.start.Add(30 * time.Minute) }
+func (f *fakeTask) Name() string     { return f.name }
+func (f *fakeTask) Execute(context.Context, *utils.Config) error {
+	f.runs++
+	return f.err
+}
+
+// newDoneCron returns a cron whose parent is already done so Run returns after a single pass
+func newDoneCron(st SequentialTasks) *Cron {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	return NewCron(ctx, st, &utils.Config{})
+}
+
+func TestCron_Schedule(t *testing.T) {
+	now := time.Now()
+	joined := &fakeTask{name: "joined", start: now}
+	skipped := &fakeTask{name: "skipped", start: now, err: fmt.Errorf("too old: %w", ErrSkipped)}
+	failed := &fakeTask{name: "failed", start: now, err: errors.New("no browser")}
+	later := &fakeTask{name: "later", start: now.Add(time.Hour)}
+
+	c := newDoneCron(SequentialTasks{joined, skipped, failed, later})
+	c.Run()
+	c.Run() // a second pass must not run the tasks again
+
+	want := map[string]TaskState{
+		"joined":  StateRunning,
+		"skipped": StateSkipped,
+		"failed":  StateFailed,
+		"later":   StatePending,
+	}
+
+	got := c.Schedule()
+	if len(got) != len(want) {
+		t.Fatalf("Cron.Schedule() returned %d tasks, want %d", len(got), len(want))
+	}
+
+	for _, st := range got {
+		if st.State != want[st.Task.Name()] {
+			t.Errorf("Cron.Schedule() %s state = %s, want %s", st.Task.Name(), st.State, want[st.Task.Name()])
+		}
+		if !st.FireTime.Equal(st.Task.Start().Add(-time.Duration(MAGIC_DELTA) * time.Second)) {
+			t.Errorf("Cron.Schedule() %s fire time = %s", st.Task.Name(), st.FireTime)
+		}
+	}
+
+	for _, f := range []*fakeTask{joined, skipped, failed} {
+		if f.runs != 1 {
+			t.Errorf("task %s ran %d times, want 1", f.name, f.runs)
+		}
+	}
+
+	next, ok := c.Next()
+	if !ok || next.Task != later {
+		t.Errorf("Cron.Next() = %v, want %s", next.Task, later.name)
+	}
+}
+
+func TestCron_Overrides(t *testing.T) {
+	now := time.Now()
+	skip := &fakeTask{name: "skip", start: now}
+	snooze := &fakeTask{name: "snooze", start: now.Add(5 * time.Minute)}
+	force := &fakeTask{name: "force", start: now.Add(2 * time.Hour)}
+
+	c := newDoneCron(SequentialTasks{skip, snooze, force})
+	if err := c.Skip("missing"); !errors.Is(err, ErrUnknownTask) {
+		t.Errorf("Cron.Skip() of an unknown task error = %v, want %v", err, ErrUnknownTask)
+	}
+	if err := c.Snooze(TaskID(snooze), 30*time.Minute); !errors.Is(err, ErrSnoozeTooLong) {
+		t.Errorf("Cron.Snooze() of 30 minutes error = %v, want %v", err, ErrSnoozeTooLong)
+	}
+
+	for _, err := range []error{
+		c.Skip(TaskID(skip)),
+		c.Snooze(TaskID(snooze), 10*time.Minute),
+		c.ForceJoin(TaskID(force)),
+	} {
+		if err != nil {
+			t.Fatalf("override error = %v", err)
+		}
+	}
+
+	<-c.wake // ForceJoin woke the cron, this test drives Run itself
+
+	// a calendar poll brings new copies of the same meetings, the overrides have to stick
+	c.internalUpdate(SequentialTasks{
+		&fakeTask{name: "skip", start: skip.start},
+		&fakeTask{name: "snooze", start: snooze.start},
+		&fakeTask{name: "force", start: force.start},
+	})
+	c.Run()
+
+	want := map[string]TaskState{
+		"skip":   StateSkipped,
+		"snooze": StatePending,
+		"force":  StateRunning,
+	}
+	for _, st := range c.Schedule() {
+		if st.State != want[st.Task.Name()] {
+			t.Errorf("%s state = %s, want %s", st.Task.Name(), st.State, want[st.Task.Name()])
+		}
+		if st.Task.Name() == "snooze" && !st.FireTime.Equal(FireTime(st.Task).Add(10*time.Minute)) {
+			t.Errorf("snoozed fire time = %s, want %s", st.FireTime, FireTime(st.Task).Add(10*time.Minute))
+		}
+	}
+
+	if err := c.ClearOverride(TaskID(snooze)); err != nil {
+		t.Fatalf("Cron.ClearOverride() error = %v", err)
+	}
+	c.Run()
+	for _, st := range c.Schedule() {
+		if st.Task.Name() == "snooze" && st.State != StateRunning {
+			t.Errorf("cleared snooze state = %s, want %s", st.State, StateRunning)
+		}
+	}
+}
+
+func TestCron_Retry(t *testing.T) {
+	flaky := &fakeTask{name: "flaky", start: time.Now(), err: fmt.Errorf("%w: chrome did not start", ErrRetryable)}
+	c := newDoneCron(SequentialTasks{flaky})
+
+	for i := 1; i <= MAX_ATTEMPTS+1; i++ {
+		c.Run()
+	}
+
+	if flaky.runs != MAX_ATTEMPTS {
+		t.Errorf("retryable task ran %d times, want %d", flaky.runs, MAX_ATTEMPTS)
+	}
+
+	st := c.Schedule()[0]
+	if st.State != StateFailed || st.Attempts != MAX_ATTEMPTS {
+		t.Errorf("retryable task state = %s after %d attempts, want %s after %d", st.State, st.Attempts, StateFailed, MAX_ATTEMPTS)
+	}
+}
+
+func TestCron_Queue(t *testing.T) {
+	now := time.Now()
+	calendar := &fakeTask{name: "calendar", start: now.Add(time.Hour)}
+	adhoc := &fakeTask{name: "adhoc", start: now}
+
+	c := newDoneCron(SequentialTasks{calendar})
+	id, err := c.Queue(adhoc)
+	if err != nil || id != TaskID(adhoc) {
+		t.Fatalf("Cron.Queue() = %s, %v want %s", id, err, TaskID(adhoc))
+	}
+	<-c.wake // the queue woke the cron, this test drives Run itself
+
+	if _, err := c.Queue(&fakeTask{name: "adhoc", start: now}); !errors.Is(err, ErrDuplicateTask) {
+		t.Errorf("Cron.Queue() of the same meeting error = %v, want %v", err, ErrDuplicateTask)
+	}
+	if _, err := c.Queue(&fakeTask{name: "over", start: now.Add(-time.Hour)}); !errors.Is(err, ErrTaskOver) {
+		t.Errorf("Cron.Queue() of a meeting that ended error = %v, want %v", err, ErrTaskOver)
+	}
+
+	// the next calendar poll only knows about the calendar meeting
+	c.internalUpdate(SequentialTasks{&fakeTask{name: "calendar", start: calendar.start}})
+	c.Run()
+
+	got := c.Schedule()
+	if len(got) != 2 || got[0].Task.Name() != "adhoc" || !got[0].AdHoc || got[1].AdHoc {
+		t.Fatalf("Cron.Schedule() = %+v, want the queued task first and marked ad hoc", got)
+	}
+	if adhoc.runs != 1 || got[0].State != StateRunning {
+		t.Errorf("queued task ran %d times in state %s, want once and %s", adhoc.runs, got[0].State, StateRunning)
+	}
+}
+
+func TestCron_Trace(t *testing.T) {
+	rec := tracetest.NewSpanRecorder()
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec)))
+	defer otel.SetTracerProvider(prev)
+
+	task := &fakeTask{name: "standup", start: time.Now(), err: errors.New("no browser")}
+	c := newDoneCron(SequentialTasks{task})
+
+	// the calendar poll that brought the task
+	_, poll := tracer.Start(context.Background(), "calendar.poll")
+	poll.End()
+	c.poll = poll.SpanContext()
+	c.Run()
+
+	spans := map[string]sdktrace.ReadOnlySpan{}
+	for _, sp := range rec.Ended() {
+		spans[sp.Name()] = sp
+	}
+
+	run, exec := spans["cron.run"], spans["cron.execute"]
+	if run == nil || exec == nil {
+		t.Fatalf("recorded spans = %v, want cron.run and cron.execute", spans)
+	}
+	if len(run.Links()) != 1 || run.Links()[0].SpanContext.SpanID() != poll.SpanContext().SpanID() {
+		t.Errorf("cron.run links = %v, want the calendar poll", run.Links())
+	}
+	if exec.Parent().SpanID() != run.SpanContext().SpanID() {
+		t.Errorf("cron.execute parent = %s, want cron.run %s", exec.Parent().SpanID(), run.SpanContext().SpanID())
+	}
+	if exec.Status().Code != codes.Error {
+		t.Errorf("cron.execute status = %v, want an error for the failed task", exec.Status())
+	}
+}
+
+func TestCron_History(t *testing.T) {
+	now := time.Now()
+	c := newDoneCron(SequentialTasks{
+		&fakeTask{name: "joined", start: now},
+		&fakeTask{name: "skipped", start: now, err: fmt.Errorf("too old: %w", ErrSkipped)},
+		&fakeTask{name: "failed", start: now, err: errors.New("no browser")},
+	})
+
+	var err error
+	c.History, err = history.Open(filepath.Join(t.TempDir(), "history.jsonl"))
+	if err != nil {
+		t.Fatalf("history.Open() error = %v", err)
+	}
+	c.Run()
+
+	records, err := c.History.Query(time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatalf("Store.Query() error = %v", err)
+	}
+
+	var got []string
+	for _, r := range records {
+		got = append(got, r.Name+" "+r.Event+" "+r.Outcome)
+		if !r.Scheduled.Equal(now) {
+			t.Errorf("record %s %s scheduled = %s, want %s", r.Name, r.Event, r.Scheduled, now)
+		}
+	}
+	want := []string{
+		"joined launch RUNNING",
+		"skipped launch RUNNING",
+		"skipped skipped SKIPPED",
+		"failed launch RUNNING",
+		"failed failed FAILED",
+	}
+	if !cmp.Equal(got, want) {
+		t.Errorf("recorded history = %q, want %q", got, want)
+	}
+}
+
+func TestCron_Change(t *testing.T) {
+	now := time.Now()
+	standup := &fakeTask{name: "standup", start: now.Add(time.Hour)}
+	review := &fakeTask{name: "review", start: now.Add(2 * time.Hour)}
+	over := &fakeTask{name: "over", start: now.Add(-2 * time.Hour)}
+	c := newDoneCron(SequentialTasks{over, standup, review})
+
+	queued := &fakeTask{name: "queued", start: now.Add(3 * time.Hour)}
+	if _, err := c.Queue(queued); err != nil {
+		t.Fatalf("Cron.Queue() error = %v", err)
+	}
+	<-c.wake
+	if err := c.Skip(TaskID(standup)); err != nil {
+		t.Fatalf("Cron.Skip() error = %v", err)
+	}
+	if err := c.Skip(TaskID(review)); err != nil {
+		t.Fatalf("Cron.Skip() error = %v", err)
+	}
+
+	// the review moved, a planning meeting was added and the standup is a new copy of the same meeting
+	moved := &fakeTask{name: "review", start: now.Add(4 * time.Hour)}
+	planning := &fakeTask{name: "planning", start: now.Add(30 * time.Minute)}
+	c.Change(context.Background(), SequentialTasks{moved, planning, planning, &fakeTask{name: "standup", start: standup.start}}, []string{TaskID(review)})
+	select {
+	case <-c.wake:
+	default:
+		t.Errorf("Cron.Change() did not wake the cron")
+	}
+
+	var got []string
+	for _, task := range c.Tasks() {
+		got = append(got, task.Name()+"@"+task.Start().Format(time.Kitchen))
+	}
+	want := []string{"planning@" + planning.start.Format(time.Kitchen), "standup@" + standup.start.Format(time.Kitchen), "review@" + moved.start.Format(time.Kitchen)}
+	if !cmp.Equal(got, want) {
+		t.Errorf("Cron.Tasks() after the changes = %v, want %v", got, want)
+	}
+
+	if sched := c.Schedule(); len(sched) != 4 {
+		t.Errorf("Cron.Schedule() has %d tasks, want the queued one kept", len(sched))
+	}
+	if c.overrides[TaskID(review)] != nil {
+		t.Errorf("the override of the removed review was kept")
+	}
+	if c.overrides[TaskID(standup)] == nil {
+		t.Errorf("the override of the replaced standup was dropped")
+	}
+}
+
+func TestCron_ChangeIdle(t *testing.T) {
+	c := newDoneCron(nil)
+	c.Run() // nothing to do, the cron is idle until a change
+
+	c.Change(context.Background(), SequentialTasks{&fakeTask{name: "standup", start: time.Now()}}, nil)
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		if sched := c.Schedule(); len(sched) == 1 && sched[0].State == StateRunning {
+			return
+		}
+	}
+	t.Errorf("Cron.Change() on an idle cron did not run the task, schedule = %+v", c.Schedule())
+}
@@ -0,0 +1,156 @@
+package tasks
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrSkipped is returned (wrapped) by a Task that decided not to run
+var ErrSkipped = errors.New("task skipped")
+
+// ErrRetryable is returned (wrapped) by a Task that failed in a way that can work later
+var ErrRetryable = errors.New("task can be retried")
+
+// how often a retryable task is tried and how long to wait between the tries
+const MAX_ATTEMPTS = 3
+const RETRY_DELAY = 60 // seconds
+
+// TaskState is where a task is in its life
+type TaskState int
+
+const (
+	StatePending TaskState = iota
+	StateRunning
+	StateSkipped
+	StateFailed
+	StateDone
+)
+
+var stateNames = map[TaskState]string{
+	StatePending: "PENDING",
+	StateRunning: "RUNNING",
+	StateSkipped: "SKIPPED",
+	StateFailed:  "FAILED",
+	StateDone:    "DONE",
+}
+
+func (s TaskState) String() string {
+	return stateNames[s]
+}
+
+// taskStatus is what the cron remembers about a task it handed off
+type taskStatus struct {
+	state    TaskState
+	start    time.Time
+	err      error
+	attempts int
+}
+
+// ScheduledTask is a snapshot of a task in the cron
+type ScheduledTask struct {
+	ID       string
+	Task     Task
+	FireTime time.Time // when the cron launches the task
+	State    TaskState
+	Err      error
+	Override Override
+	Snooze   time.Duration
+	Attempts int
+	AdHoc    bool // queued by hand instead of coming from the calendar
+}
+
+// TaskID identifies a task, the same meeting can recur so the start is part of it
+func TaskID(t Task) string {
+	sum := sha1.Sum([]byte(fmt.Sprintf("%s@%d", t.Name(), t.Start().Unix())))
+	return hex.EncodeToString(sum[:])[:10]
+}
+
+// FireTime is when the cron launches the task
+func FireTime(t Task) time.Time {
+	return t.Start().Add(-time.Second * time.Duration(MAGIC_DELTA))
+}
+
+// Schedule returns a snapshot of the ordered tasks and their state
+func (c *Cron) Schedule() []ScheduledTask {
+	c.sLock.Lock()
+	defer c.sLock.Unlock()
+
+	ordered := c.merge(c.ordered) // a queued task shows up before the cron gets to it
+	ret := make([]ScheduledTask, 0, len(ordered))
+	for _, task := range ordered {
+		st := ScheduledTask{
+			ID:       TaskID(task),
+			Task:     task,
+			FireTime: FireTime(task),
+			State:    StatePending,
+		}
+		st.AdHoc = c.isAdhoc(st.ID)
+
+		if o, ok := c.overrides[st.ID]; ok {
+			st.Override = o.kind
+			st.Snooze = o.snooze
+			st.FireTime = o.fireTime(task)
+		}
+
+		if status, ok := c.states[st.ID]; ok {
+			st.State = status.state
+			st.Err = status.err
+			st.Attempts = status.attempts
+		}
+
+		// a joined meeting is running until it is over
+		if st.State == StateRunning && !task.End().IsZero() && time.Now().After(task.End()) {
+			st.State = StateDone
+		}
+
+		ret = append(ret, st)
+	}
+
+	return ret
+}
+
+// Len returns the number of tasks in the schedule
+func (c *Cron) Len() int {
+	c.sLock.Lock()
+	defer c.sLock.Unlock()
+	return len(c.ordered)
+}
+
+// Next returns the first task that is still waiting to run
+func (c *Cron) Next() (ScheduledTask, bool) {
+	for _, st := range c.Schedule() {
+		if st.State == StatePending {
+			return st, true
+		}
+	}
+	return ScheduledTask{}, false
+}
+
+// setState records the state of a task
+func (c *Cron) setState(t Task, state TaskState, err error) int {
+	c.sLock.Lock()
+	defer c.sLock.Unlock()
+
+	id := TaskID(t)
+	status := &taskStatus{state: state, start: t.Start(), err: err}
+	if prev, ok := c.states[id]; ok {
+		status.attempts = prev.attempts
+	}
+	if state == StateRunning {
+		status.attempts++
+	}
+
+	c.states[id] = status
+	return status.attempts
+}
+
+// hasRun is true when the task was already handed off and is not waiting for a retry
+func (c *Cron) hasRun(t Task) bool {
+	c.sLock.Lock()
+	defer c.sLock.Unlock()
+	status, ok := c.states[TaskID(t)]
+	return ok && status.state != StatePending
+}